@@ -0,0 +1,169 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/modelpack/model-spec/safepath"
+)
+
+// Options configures the normalization of tar headers.
+type Options struct {
+	// ModTimeClamp, when not zero, replaces the modification times later than it,
+	// following the SOURCE_DATE_EPOCH convention of reproducible builds.
+	ModTimeClamp time.Time
+
+	// Uid is the user ID recorded for every entry.
+	Uid int
+
+	// Gid is the group ID recorded for every entry.
+	Gid int
+
+	// NormalizeMode records directories and executable files as 0755 and other files as 0644,
+	// so that the umask of the packing host does not change the output.
+	NormalizeMode bool
+}
+
+// SourceDateEpoch returns the time set by the SOURCE_DATE_EPOCH environment variable,
+// or the zero time if it is not set.
+func SourceDateEpoch() (time.Time, error) {
+	v, ok := os.LookupEnv("SOURCE_DATE_EPOCH")
+	if !ok || v == "" {
+		return time.Time{}, nil
+	}
+	sec, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: %w", v, err)
+	}
+	return time.Unix(sec, 0).UTC(), nil
+}
+
+// Write writes a tar archive of the given paths to w. The paths are slash separated and relative to root,
+// directories are added with their whole content. The output only depends on the file names, content,
+// modes and modification times, and is byte-identical for identical inputs:
+// entries are sorted by name, and user names, access times and extended attributes are dropped.
+func Write(w io.Writer, root string, paths []string, opts Options) error {
+	var names []string
+	seen := make(map[string]bool)
+	for _, p := range paths {
+		if err := safepath.Validate(p); err != nil {
+			return err
+		}
+		err := filepath.WalkDir(filepath.Join(root, filepath.FromSlash(p)), func(file string, _ fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(root, file)
+			if err != nil {
+				return err
+			}
+			name := filepath.ToSlash(rel)
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to walk %s: %w", p, err)
+		}
+	}
+	slices.Sort(names)
+
+	tw := tar.NewWriter(w)
+	for _, name := range names {
+		if err := writeEntry(tw, root, name, opts); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func writeEntry(tw *tar.Writer, root, name string, opts Options) error {
+	file := filepath.Join(root, filepath.FromSlash(name))
+	fi, err := os.Lstat(file)
+	if err != nil {
+		return err
+	}
+
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    int64(fi.Mode().Perm()),
+		Uid:     opts.Uid,
+		Gid:     opts.Gid,
+		ModTime: fi.ModTime().UTC().Truncate(time.Second),
+	}
+	if !opts.ModTimeClamp.IsZero() && hdr.ModTime.After(opts.ModTimeClamp) {
+		hdr.ModTime = opts.ModTimeClamp.UTC().Truncate(time.Second)
+	}
+
+	switch {
+	case fi.Mode().IsRegular():
+		hdr.Typeflag = tar.TypeReg
+		hdr.Size = fi.Size()
+	case fi.IsDir():
+		hdr.Typeflag = tar.TypeDir
+		hdr.Name += "/"
+	case fi.Mode()&fs.ModeSymlink != 0:
+		hdr.Typeflag = tar.TypeSymlink
+		if hdr.Linkname, err = os.Readlink(file); err != nil {
+			return err
+		}
+		hdr.Linkname = filepath.ToSlash(hdr.Linkname)
+	default:
+		return fmt.Errorf("unsupported file type %s of %s", fi.Mode().Type(), name)
+	}
+
+	if opts.NormalizeMode {
+		hdr.Mode = 0o644
+		if fi.IsDir() || fi.Mode()&0o111 != 0 {
+			hdr.Mode = 0o755
+		}
+		if hdr.Typeflag == tar.TypeSymlink {
+			hdr.Mode = 0o777
+		}
+	}
+
+	// let the writer pick USTAR, falling back to PAX only for long names or large values
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to write header of %s: %w", path.Clean(name), err)
+	}
+	if hdr.Typeflag != tar.TypeReg {
+		return nil
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(tw, f); err != nil {
+		return fmt.Errorf("failed to write content of %s: %w", name, err)
+	}
+	return nil
+}
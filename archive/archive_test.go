@@ -0,0 +1,111 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelpack/model-spec/archive"
+)
+
+// writeTree creates the files in dir in the given order, with their modification time set to mtime.
+func writeTree(t *testing.T, dir string, names []string, mtime time.Time) {
+	t.Helper()
+	for _, name := range names {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(file, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Chtimes(filepath.Join(dir, "weights"), mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriteReproducible(t *testing.T) {
+	epoch := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	opts := archive.Options{ModTimeClamp: epoch, Uid: 1000, Gid: 1000, NormalizeMode: true}
+
+	var outputs [][]byte
+	for _, tree := range []struct {
+		names []string
+		mtime time.Time
+	}{
+		{names: []string{"weights/b.safetensors", "weights/a.safetensors", "config.json"}, mtime: time.Now()},
+		{names: []string{"config.json", "weights/a.safetensors", "weights/b.safetensors"}, mtime: time.Now().Add(time.Hour)},
+	} {
+		dir := t.TempDir()
+		writeTree(t, dir, tree.names, tree.mtime)
+
+		var buf bytes.Buffer
+		if err := archive.Write(&buf, dir, []string{"weights", "config.json"}, opts); err != nil {
+			t.Fatal(err)
+		}
+		outputs = append(outputs, buf.Bytes())
+	}
+	if !bytes.Equal(outputs[0], outputs[1]) {
+		t.Fatal("expected identical archives for identical inputs")
+	}
+
+	var names []string
+	tr := tar.NewReader(bytes.NewReader(outputs[0]))
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, hdr.Name)
+		if !hdr.ModTime.Equal(epoch) {
+			t.Errorf("%s: expected clamped mtime %s, got %s", hdr.Name, epoch, hdr.ModTime)
+		}
+		if hdr.Uid != 1000 || hdr.Gid != 1000 || hdr.Uname != "" || hdr.Gname != "" {
+			t.Errorf("%s: expected normalized ownership, got %d:%d (%s:%s)", hdr.Name, hdr.Uid, hdr.Gid, hdr.Uname, hdr.Gname)
+		}
+	}
+	expected := []string{"config.json", "weights/", "weights/a.safetensors", "weights/b.safetensors"}
+	if len(names) != len(expected) {
+		t.Fatalf("expected entries %v, got %v", expected, names)
+	}
+	for i := range names {
+		if names[i] != expected[i] {
+			t.Errorf("expected entries %v, got %v", expected, names)
+			break
+		}
+	}
+}
+
+func TestWriteUnsafePath(t *testing.T) {
+	if err := archive.Write(io.Discard, t.TempDir(), []string{"../etc"}, archive.Options{}); err == nil {
+		t.Error("expected unsafe path to fail")
+	}
+}
@@ -0,0 +1,202 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package bom exports an AI bill of materials of a model, as a CycloneDX 1.6 ML-BOM or
+// an SPDX 3.0.1 document using the AI profile, from its config and manifest.
+//
+// The generated documents are validated: CycloneDX documents against the embedded CycloneDX 1.6
+// JSON schema, SPDX documents against the SPDX 3.0.1 model and the properties the AI profile requires.
+//
+// Conversely, a model descriptor and config are imported from a CycloneDX machine-learning-model
+// component and its model card, reporting the information the spec cannot represent.
+package bom
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// Options are the optional inputs of the exporters.
+type Options struct {
+	// Digest is the digest of the model manifest, recorded as the hash of the model when set.
+	Digest digest.Digest
+
+	// Created is the creation time of the document. It defaults to the creation time of the model,
+	// or to the current time.
+	Created time.Time
+
+	// Creator is the name of the tool or organization creating the document, "modelpack" by default.
+	Creator string
+}
+
+func (o Options) created(model v1.Model) time.Time {
+	switch {
+	case !o.Created.IsZero():
+		return o.Created.UTC().Truncate(time.Second)
+	case model.Descriptor.CreatedAt != nil:
+		return model.Descriptor.CreatedAt.UTC().Truncate(time.Second)
+	default:
+		return time.Now().UTC().Truncate(time.Second)
+	}
+}
+
+func (o Options) creator() string {
+	if o.Creator != "" {
+		return o.Creator
+	}
+	return "modelpack"
+}
+
+//go:embed schemas/*.json
+var schemas embed.FS
+
+// schemaURLs maps the embedded schema files to the URL they are loaded at.
+var schemaURLs = map[string]string{
+	"bom-1.6.schema.json":  "http://cyclonedx.org/schema/bom-1.6.schema.json",
+	"spdx.schema.json":     "http://cyclonedx.org/schema/spdx.schema.json",
+	"jsf-0.82.schema.json": "http://cyclonedx.org/schema/jsf-0.82.schema.json",
+}
+
+var compileSchema = sync.OnceValues(func() (*jsonschema.Schema, error) {
+	c := jsonschema.NewCompiler()
+	c.AssertFormat = true
+	for file, url := range schemaURLs {
+		buf, err := schemas.ReadFile("schemas/" + file)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.AddResource(url, bytes.NewReader(buf)); err != nil {
+			return nil, fmt.Errorf("failed to add schema %s: %w", file, err)
+		}
+	}
+	s, err := c.Compile(schemaURLs["bom-1.6.schema.json"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema bom-1.6.schema.json: %w", err)
+	}
+	return s, nil
+})
+
+// ValidateCycloneDX validates a document against the CycloneDX 1.6 JSON schema.
+func ValidateCycloneDX(doc []byte) error {
+	schema, err := compileSchema()
+	if err != nil {
+		return err
+	}
+	var v any
+	if err := json.Unmarshal(doc, &v); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if err := schema.Validate(v); err != nil {
+		return fmt.Errorf("invalid document: %w", err)
+	}
+	return nil
+}
+
+// uuid returns a name-based UUID (version 8) derived from the manifest config digest,
+// so the documents of a model have a stable identifier.
+func uuid(manifest v1.ModelManifest) string {
+	sum := sha256.Sum256([]byte("modelpack:" + manifest.Config.Digest.String()))
+	sum[6] = sum[6]&0x0f | 0x80
+	sum[8] = sum[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// layerPath returns the file path of a layer, or its digest.
+func layerPath(layer ocispec.Descriptor) string {
+	if path := layer.Annotations[v1.AnnotationFilepath]; path != "" {
+		return path
+	}
+	return layer.Digest.String()
+}
+
+// configProperties flattens the config into sorted key and value pairs named by their JSON path,
+// such as "runtime.maxContextLength", leaving out the fields under the skipped paths.
+// Lists of scalars are comma-separated, other lists are compact JSON.
+func configProperties(config v1.ModelConfig, skip ...string) ([][2]string, error) {
+	buf, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(buf, &fields); err != nil {
+		return nil, err
+	}
+	var all, properties [][2]string
+	flatten("", fields, &all)
+	for _, p := range all {
+		if !slices.ContainsFunc(skip, func(s string) bool { return p[0] == s || strings.HasPrefix(p[0], s+".") }) {
+			properties = append(properties, p)
+		}
+	}
+	sort.Slice(properties, func(i, j int) bool { return properties[i][0] < properties[j][0] })
+	return properties, nil
+}
+
+func flatten(prefix string, v any, properties *[][2]string) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			flatten(prefix+key+".", value, properties)
+		}
+		return
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			if _, ok := item.(map[string]any); ok {
+				buf, _ := json.Marshal(v)
+				*properties = append(*properties, [2]string{strings.TrimSuffix(prefix, "."), string(buf)})
+				return
+			}
+			items[i] = scalar(item)
+		}
+		*properties = append(*properties, [2]string{strings.TrimSuffix(prefix, "."), strings.Join(items, ", ")})
+	default:
+		*properties = append(*properties, [2]string{strings.TrimSuffix(prefix, "."), scalar(v)})
+	}
+}
+
+func scalar(v any) string {
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// licenseExpression combines the licenses of the descriptor into a single SPDX license expression.
+func licenseExpression(licenses []string) string {
+	if len(licenses) == 1 {
+		return licenses[0]
+	}
+	parts := make([]string, len(licenses))
+	for i, l := range licenses {
+		parts[i] = "(" + l + ")"
+	}
+	return strings.Join(parts, " AND ")
+}
@@ -0,0 +1,375 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bom_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelpack/model-spec/bom"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+)
+
+func model() (v1.Model, v1.ModelManifest) {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	yes := true
+	m := v1.Model{
+		Descriptor: v1.ModelDescriptor{
+			CreatedAt:   &created,
+			Authors:     []string{"Jane Doe <jane@example.com>"},
+			Family:      "llama3",
+			Name:        "llama3-8b-instruct",
+			DocURL:      "https://example.com/llama3",
+			Version:     "1.0",
+			Vendor:      "Example",
+			Licenses:    []string{"Apache-2.0", "MIT"},
+			DatasetsURL: []string{"https://huggingface.co/datasets/HuggingFaceFW/fineweb"},
+			Lineage: &v1.ModelLineage{Parents: []v1.ParentModel{
+				{Reference: "registry.example.com/models/llama3:8b", Digest: digest.FromString("parent"), Relationship: v1.RelationshipFinetune},
+			}},
+		},
+		Config: v1.ModelConfig{
+			Architecture: "transformer",
+			Format:       "safetensors",
+			ParamSize:    "8b",
+			Precision:    "bfloat16",
+			Runtime:      &v1.ModelRuntime{MaxContextLength: 8192, StopSequences: []string{"</s>"}},
+			Capabilities: &v1.ModelCapabilities{
+				InputTypes:  []v1.Modality{v1.TextModality},
+				OutputTypes: []v1.Modality{v1.TextModality},
+				ToolUsage:   &yes,
+			},
+		},
+		ModelFS: v1.ModelFS{Type: "layers"},
+	}
+	manifest := v1.NewModelManifest(
+		v1.NewConfigDescriptor(digest.FromString("config"), 100),
+		v1.NewLayerDescriptor(v1.MediaTypeModelWeightRaw, digest.FromString("weights"), 1<<30, "model.safetensors"),
+		v1.NewLayerDescriptor(v1.MediaTypeModelDoc, digest.FromString("docs"), 1024, ""),
+	)
+	return m, manifest
+}
+
+func TestCycloneDX(t *testing.T) {
+	m, manifest := model()
+	opts := bom.Options{Digest: digest.FromString("manifest")}
+	doc, err := bom.CycloneDX(m, manifest, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := bom.CycloneDX(m, manifest, opts)
+	if err != nil || !bytes.Equal(doc, again) {
+		t.Errorf("expected a reproducible document, got %v", err)
+	}
+
+	var b bom.BOM
+	if err := json.Unmarshal(doc, &b); err != nil {
+		t.Fatal(err)
+	}
+	c := b.Metadata.Component
+	if b.SpecVersion != "1.6" || b.Metadata.Timestamp != "2025-01-02T03:04:05Z" || c.Type != "machine-learning-model" || c.Name != "llama3-8b-instruct" {
+		t.Errorf("unexpected metadata %+v", b.Metadata)
+	}
+	if len(c.Licenses) != 1 || c.Licenses[0].Expression != "(Apache-2.0) AND (MIT)" {
+		t.Errorf("unexpected licenses %+v", c.Licenses)
+	}
+	if len(c.Hashes) != 1 || c.Hashes[0].Algorithm != "SHA-256" || c.Hashes[0].Content != digest.FromString("manifest").Encoded() {
+		t.Errorf("unexpected hashes %+v", c.Hashes)
+	}
+	p := c.ModelCard.ModelParameters
+	if p.ArchitectureFamily != "transformer" || p.ModelArchitecture != "llama3" || len(p.Inputs) != 1 || len(p.Datasets) != 1 || p.Datasets[0].Ref != "dataset-0" {
+		t.Errorf("unexpected model parameters %+v", p)
+	}
+	properties := make(map[string]string)
+	for _, p := range c.Properties {
+		properties[p.Name] = p.Value
+	}
+	for name, value := range map[string]string{
+		"modelpack:config.paramSize":                "8b",
+		"modelpack:config.runtime.maxContextLength": "8192",
+		"modelpack:config.capabilities.toolUsage":   "true",
+	} {
+		if properties[name] != value {
+			t.Errorf("expected property %s=%s, got %q", name, value, properties[name])
+		}
+	}
+	if len(c.Pedigree.Ancestors) != 1 || c.Pedigree.Ancestors[0].Name != "registry.example.com/models/llama3:8b" {
+		t.Errorf("unexpected pedigree %+v", c.Pedigree)
+	}
+	if len(b.Components) != 3 || b.Components[2].Name != digest.FromString("docs").String() || len(b.Dependencies[0].DependsOn) != 3 {
+		t.Errorf("unexpected components %+v", b.Components)
+	}
+
+	m.Descriptor.Name = ""
+	if _, err := bom.CycloneDX(m, manifest, opts); err == nil {
+		t.Errorf("expected failure for a model without name")
+	}
+}
+
+func TestSPDX(t *testing.T) {
+	m, manifest := model()
+	doc, err := bom.SPDX(m, manifest, bom.Options{Created: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), Creator: "Example CI"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var d struct {
+		Graph []map[string]any `json:"@graph"`
+	}
+	if err := json.Unmarshal(doc, &d); err != nil {
+		t.Fatal(err)
+	}
+	types := make(map[string]int)
+	relationships := make(map[string]int)
+	var model map[string]any
+	for _, n := range d.Graph {
+		types[n["type"].(string)]++
+		if n["type"] == "Relationship" {
+			relationships[n["relationshipType"].(string)]++
+		}
+		if id, _ := n["spdxId"].(string); strings.HasSuffix(id, "#SPDXRef-Model") {
+			model = n
+		}
+	}
+	for typ, count := range map[string]int{"CreationInfo": 1, "SpdxDocument": 1, "ai_AIPackage": 1, "software_Package": 1, "software_File": 2, "dataset_DatasetPackage": 1, "Agent": 3} {
+		if types[typ] != count {
+			t.Errorf("expected %d %s, got %d", count, typ, types[typ])
+		}
+	}
+	for typ, count := range map[string]int{"contains": 1, "trainedOn": 1, "descendantOf": 1, "hasDeclaredLicense": 1} {
+		if relationships[typ] != count {
+			t.Errorf("expected %d %s relationships, got %d", count, typ, relationships[typ])
+		}
+	}
+	if model == nil || model["releaseTime"] != "2025-01-02T03:04:05Z" || model["ai_informationAboutApplication"] != "inputTypes: text; outputTypes: text; toolUsage: true" {
+		t.Errorf("unexpected model %v", model)
+	}
+	if !strings.Contains(string(doc), `"created": "2025-02-01T00:00:00Z"`) {
+		t.Errorf("expected the creation time of the options")
+	}
+
+	m.Descriptor.CreatedAt, m.Descriptor.Vendor, m.Descriptor.Version = nil, "", ""
+	doc, err = bom.SPDX(m, manifest, bom.Options{Created: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{`"releaseTime": "2025-02-01T00:00:00Z"`, `"name": "NOASSERTION"`, `"software_packageVersion": "NOASSERTION"`} {
+		if !strings.Contains(string(doc), s) {
+			t.Errorf("expected %s in a document of a model without creation time, vendor and version", s)
+		}
+	}
+}
+
+// spdxDoc returns an SPDX document whose root element has the given type and properties.
+func spdxDoc(typ, properties string) string {
+	return `{"@context": "https://spdx.org/rdf/3.0.1/spdx-context.jsonld", "@graph": [
+  {"type": "CreationInfo", "@id": "_:c", "specVersion": "3.0.1", "created": "2025-01-01T00:00:00Z", "createdBy": ["urn:x#a"]},
+  {"type": "Agent", "spdxId": "urn:x#a", "creationInfo": "_:c", "name": "a"},
+  {"type": "SpdxDocument", "spdxId": "urn:x#d", "creationInfo": "_:c", "rootElement": ["urn:x#p"], "element": ["urn:x#a", "urn:x#p"]},
+  {"type": "` + typ + `", "spdxId": "urn:x#p", "creationInfo": "_:c", ` + properties + `}
+]}`
+}
+
+// aiPackage holds the properties the AI profile requires on an AI package.
+const aiPackage = `"name": "m", "suppliedBy": "urn:x#a", "releaseTime": "2025-01-01T00:00:00Z", "software_downloadLocation": "https://example.com/m", "software_packageVersion": "1.0"`
+
+func TestValidate(t *testing.T) {
+	for i, tt := range []struct {
+		validate func([]byte) error
+		doc      string
+		fail     bool
+	}{
+		{validate: bom.ValidateCycloneDX, doc: `{"bomFormat": "CycloneDX", "specVersion": "1.6"}`},
+		{validate: bom.ValidateCycloneDX, doc: `{"bomFormat": "SPDX", "specVersion": "1.6"}`, fail: true},
+		{validate: bom.ValidateCycloneDX, doc: `{"bomFormat": "CycloneDX", "specVersion": "1.6", "components": [{"type": "model", "name": "x"}]}`, fail: true},
+		{validate: bom.ValidateCycloneDX, doc: `{`, fail: true},
+		{validate: bom.ValidateSPDX, doc: spdxDoc("software_Package", `"name": "p"`)},
+		{validate: bom.ValidateSPDX, doc: spdxDoc("ai_AIPackage", aiPackage+`, "software_primaryPurpose": "model"`)},
+		{validate: bom.ValidateSPDX, doc: spdxDoc("ai_AIPackage", `"name": "m", "software_primaryPurpose": "model"`), fail: true},
+		{validate: bom.ValidateSPDX, doc: spdxDoc("ai_AIPackage", aiPackage+`, "software_primaryPurpose": "weights"`), fail: true},
+		{validate: bom.ValidateSPDX, doc: spdxDoc("software_Package", `"name": "p"`)[1:], fail: true},
+		{validate: bom.ValidateSPDX, doc: strings.Replace(spdxDoc("software_Package", `"name": "p"`), `"specVersion": "3.0.1", `, "", 1), fail: true},
+	} {
+		if err := tt.validate([]byte(tt.doc)); (err != nil) != tt.fail {
+			t.Errorf("test %d: expected failure %t, got %v", i, tt.fail, err)
+		}
+	}
+}
+
+func TestImportCycloneDX(t *testing.T) {
+	m, manifest := model()
+	doc, err := bom.CycloneDX(m, manifest, bom.Options{Digest: digest.FromString("manifest")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := bom.ImportCycloneDX(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := m
+	expected.Descriptor.CreatedAt = nil
+	expected.ModelFS = v1.ModelFS{}
+	if !reflect.DeepEqual(r.Model, expected) {
+		t.Errorf("expected %+v, got %+v", expected, r.Model)
+	}
+	if !reflect.DeepEqual(r.Unmapped, []string{"hashes"}) {
+		t.Errorf("unexpected unmapped fields %v", r.Unmapped)
+	}
+
+	if _, err := bom.ImportCycloneDX([]byte(`{"bomFormat": "CycloneDX", "specVersion": "1.6", "components": [{"type": "library", "name": "x"}]}`)); err == nil {
+		t.Errorf("expected failure without machine-learning-model component")
+	}
+}
+
+const supplierComponent = `{
+  "type": "machine-learning-model",
+  "name": "sentiment",
+  "group": "example",
+  "supplier": {"name": "Example", "url": ["https://example.com"]},
+  "authors": [{"name": "Jane Doe", "email": "jane@example.com"}],
+  "licenses": [{"license": {"id": "MIT"}}, {"license": {"name": "Custom"}}],
+  "externalReferences": [{"type": "vcs", "url": "https://github.com/example/sentiment"}, {"type": "website", "url": "https://example.com"}],
+  "modelCard": {
+    "modelParameters": {
+      "task": "text-classification",
+      "architectureFamily": "transformer",
+      "modelArchitecture": "bert",
+      "inputs": [{"format": "text"}],
+      "outputs": [{"format": "label"}],
+      "datasets": [{"type": "dataset", "name": "reviews", "contents": {"url": "https://example.com/reviews"}}, {"ref": "dataset-0"}]
+    },
+    "considerations": {"users": ["analysts"]}
+  },
+  "properties": [
+    {"name": "modelpack:config.paramSize", "value": "110m"},
+    {"name": "modelpack:config.runtime.maxContextLength", "value": "512"},
+    {"name": "modelpack:config.capabilities.languages", "value": "en, eng"},
+    {"name": "modelpack:config.capabilities.reasoning", "value": "maybe"},
+    {"name": "modelpack:config.unknown", "value": "x"},
+    {"name": "example:team", "value": "nlp"}
+  ]
+}`
+
+func TestImportComponent(t *testing.T) {
+	r, err := bom.ImportComponent([]byte(supplierComponent))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, c := r.Model.Descriptor, r.Model.Config
+	if d.Name != "sentiment" || d.Vendor != "Example" || d.Family != "bert" || d.SourceURL != "https://github.com/example/sentiment" || d.DocURL != "" {
+		t.Errorf("unexpected descriptor %+v", d)
+	}
+	if !reflect.DeepEqual(d.Authors, []string{"Jane Doe <jane@example.com>"}) || !reflect.DeepEqual(d.Licenses, []string{"MIT"}) ||
+		!reflect.DeepEqual(d.DatasetsURL, []string{"https://example.com/reviews"}) {
+		t.Errorf("unexpected descriptor lists %+v", d)
+	}
+	if c.Architecture != "transformer" || c.ParamSize != "110m" || c.Runtime == nil || c.Runtime.MaxContextLength != 512 {
+		t.Errorf("unexpected config %+v", c)
+	}
+	if caps := c.Capabilities; caps == nil || !reflect.DeepEqual(caps.InputTypes, []v1.Modality{v1.TextModality}) ||
+		caps.OutputTypes != nil || !reflect.DeepEqual(caps.Languages, []string{"en"}) || caps.Reasoning != nil {
+		t.Errorf("unexpected capabilities %+v", caps)
+	}
+	expected := []string{
+		"externalReferences.website=https://example.com",
+		"group",
+		"licenses.license.name=Custom",
+		"modelCard.considerations",
+		"modelCard.modelParameters.datasets.ref=dataset-0",
+		"modelCard.modelParameters.outputs.format=label",
+		"modelCard.modelParameters.task",
+		"properties.example:team",
+		"properties.modelpack:config.capabilities.languages=eng",
+		"properties.modelpack:config.capabilities.reasoning=maybe",
+		"properties.modelpack:config.unknown",
+		"supplier.url",
+	}
+	if !reflect.DeepEqual(r.Unmapped, expected) {
+		t.Errorf("expected unmapped fields %v, got %v", expected, r.Unmapped)
+	}
+
+	for i, tt := range []string{
+		`{"type": "library", "name": "x"}`,
+		`{"type": "machine-learning-model"}`,
+	} {
+		if _, err := bom.ImportComponent([]byte(tt)); err == nil {
+			t.Errorf("test %d: expected failure", i)
+		}
+	}
+}
+
+func TestImportComponentReported(t *testing.T) {
+	for i, tt := range []struct {
+		component   string
+		unmapped    []string
+		parents     int
+		inputTypes  []v1.Modality
+		outputTypes []v1.Modality
+	}{
+		{
+			component: `{"type": "machine-learning-model", "name": "x", "properties": [{"name": "modelpack:config.architectureDetails.hiddenSize", "value": "4096"}, {"name": "modelpack:config.architectureDetails.numAttentionHeads", "value": "24"}, {"name": "modelpack:config.runtime.maxContextLength", "value": "512"}]}`,
+			unmapped:  []string{"properties.modelpack:config.architectureDetails.hiddenSize=4096", "properties.modelpack:config.architectureDetails.numAttentionHeads=24"},
+		},
+		{
+			component: `{"type": "machine-learning-model", "name": "x", "properties": [{"name": "modelpack:config.capabilities.outputTypes", "value": "text, smell"}]}`,
+			unmapped:  []string{"properties.modelpack:config.capabilities.outputTypes=smell"},
+		},
+		{
+			component: `{"type": "machine-learning-model", "name": "x",
+  "modelCard": {"modelParameters": {"inputs": [{"format": "text"}, {"format": "image"}], "outputs": [{"format": "text"}]}},
+  "properties": [{"name": "modelpack:config.capabilities.inputTypes", "value": "text, text"}, {"name": "modelpack:config.capabilities.outputTypes", "value": "text"}]}`,
+			inputTypes:  []v1.Modality{v1.TextModality, v1.ImageModality},
+			outputTypes: []v1.Modality{v1.TextModality},
+		},
+		{
+			component: `{"type": "machine-learning-model", "name": "x", "pedigree": {"ancestors": [
+  {"type": "machine-learning-model", "name": "base", "properties": [{"name": "modelpack:relationship", "value": "distilled"}]},
+  {"type": "machine-learning-model", "name": "pretrained", "properties": [{"name": "modelpack:relationship", "value": "pretrain"}]},
+  {"type": "machine-learning-model", "name": "unknown"}
+]}}`,
+			unmapped: []string{"pedigree.ancestors.properties.modelpack:relationship=pretrain", "pedigree.ancestors=pretrained", "pedigree.ancestors=unknown"},
+			parents:  1,
+		},
+		{
+			component: `{"type": "machine-learning-model", "name": "x", "pedigree": {"ancestors": [{"type": "machine-learning-model", "name": "unknown"}]}}`,
+			unmapped:  []string{"pedigree.ancestors=unknown"},
+		},
+	} {
+		r, err := bom.ImportComponent([]byte(tt.component))
+		if err != nil {
+			t.Errorf("test %d: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(r.Unmapped, tt.unmapped) {
+			t.Errorf("test %d: expected unmapped fields %v, got %v", i, tt.unmapped, r.Unmapped)
+		}
+		if err := r.Model.Config.Validate(); err != nil {
+			t.Errorf("test %d: inconsistent config: %v", i, err)
+		}
+		if caps := r.Model.Config.Capabilities; tt.inputTypes != nil && (caps == nil || !reflect.DeepEqual(caps.InputTypes, tt.inputTypes) || !reflect.DeepEqual(caps.OutputTypes, tt.outputTypes)) {
+			t.Errorf("test %d: expected modalities %v and %v, got %+v", i, tt.inputTypes, tt.outputTypes, caps)
+		}
+		if lineage := r.Model.Descriptor.Lineage; (lineage == nil && tt.parents > 0) || (lineage != nil && len(lineage.Parents) != tt.parents) {
+			t.Errorf("test %d: expected %d parents, got %+v", i, tt.parents, lineage)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bom
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+)
+
+// CycloneDX document types, limited to the fields used to describe models.
+type (
+	// BOM is a CycloneDX bill of materials.
+	BOM struct {
+		BOMFormat    string       `json:"bomFormat"`
+		SpecVersion  string       `json:"specVersion"`
+		SerialNumber string       `json:"serialNumber,omitempty"`
+		Version      int          `json:"version,omitempty"`
+		Metadata     *Metadata    `json:"metadata,omitempty"`
+		Components   []Component  `json:"components,omitempty"`
+		Dependencies []Dependency `json:"dependencies,omitempty"`
+	}
+
+	// Metadata describes the BOM and the component it is about.
+	Metadata struct {
+		Timestamp string     `json:"timestamp,omitempty"`
+		Tools     *Tools     `json:"tools,omitempty"`
+		Component *Component `json:"component,omitempty"`
+	}
+
+	// Tools lists the tools used to create the BOM.
+	Tools struct {
+		Components []Component `json:"components,omitempty"`
+	}
+
+	// Component is a CycloneDX component, such as a machine-learning-model, a file or data.
+	Component struct {
+		Type               string                  `json:"type"`
+		MimeType           string                  `json:"mime-type,omitempty"`
+		BOMRef             string                  `json:"bom-ref,omitempty"`
+		Supplier           *OrganizationalEntity   `json:"supplier,omitempty"`
+		Authors            []OrganizationalContact `json:"authors,omitempty"`
+		Group              string                  `json:"group,omitempty"`
+		Name               string                  `json:"name"`
+		Version            string                  `json:"version,omitempty"`
+		Description        string                  `json:"description,omitempty"`
+		Hashes             []Hash                  `json:"hashes,omitempty"`
+		Licenses           []LicenseChoice         `json:"licenses,omitempty"`
+		Pedigree           *Pedigree               `json:"pedigree,omitempty"`
+		ExternalReferences []ExternalReference     `json:"externalReferences,omitempty"`
+		ModelCard          *ModelCard              `json:"modelCard,omitempty"`
+		Data               []ComponentData         `json:"data,omitempty"`
+		Properties         []Property              `json:"properties,omitempty"`
+		Tags               []string                `json:"tags,omitempty"`
+	}
+
+	// OrganizationalEntity is an organization, such as the supplier of a component.
+	OrganizationalEntity struct {
+		Name string   `json:"name,omitempty"`
+		URL  []string `json:"url,omitempty"`
+	}
+
+	// OrganizationalContact is a person or organization, such as an author.
+	OrganizationalContact struct {
+		Name  string `json:"name,omitempty"`
+		Email string `json:"email,omitempty"`
+	}
+
+	// Hash is the hash of a component.
+	Hash struct {
+		Algorithm string `json:"alg"`
+		Content   string `json:"content"`
+	}
+
+	// LicenseChoice is either a license or an SPDX license expression.
+	LicenseChoice struct {
+		License    *License `json:"license,omitempty"`
+		Expression string   `json:"expression,omitempty"`
+	}
+
+	// License is a license identified by its SPDX identifier or its name.
+	License struct {
+		ID   string `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
+		URL  string `json:"url,omitempty"`
+	}
+
+	// Pedigree lists the components a component derives from.
+	Pedigree struct {
+		Ancestors []Component `json:"ancestors,omitempty"`
+		Notes     string      `json:"notes,omitempty"`
+	}
+
+	// ExternalReference is a URL related to a component.
+	ExternalReference struct {
+		Type    string `json:"type"`
+		URL     string `json:"url"`
+		Comment string `json:"comment,omitempty"`
+	}
+
+	// ModelCard is the model card of a machine-learning-model component.
+	ModelCard struct {
+		BOMRef               string           `json:"bom-ref,omitempty"`
+		ModelParameters      *ModelParameters `json:"modelParameters,omitempty"`
+		QuantitativeAnalysis json.RawMessage  `json:"quantitativeAnalysis,omitempty"`
+		Considerations       *Considerations  `json:"considerations,omitempty"`
+		Properties           []Property       `json:"properties,omitempty"`
+	}
+
+	// ModelParameters are the parameters of the construction of a model.
+	ModelParameters struct {
+		Approach           *Approach       `json:"approach,omitempty"`
+		Task               string          `json:"task,omitempty"`
+		ArchitectureFamily string          `json:"architectureFamily,omitempty"`
+		ModelArchitecture  string          `json:"modelArchitecture,omitempty"`
+		Datasets           []DatasetChoice `json:"datasets,omitempty"`
+		Inputs             []MLParameter   `json:"inputs,omitempty"`
+		Outputs            []MLParameter   `json:"outputs,omitempty"`
+	}
+
+	// Approach is the learning approach of a model.
+	Approach struct {
+		Type string `json:"type,omitempty"`
+	}
+
+	// DatasetChoice is either a reference to a data component or inline data.
+	DatasetChoice struct {
+		Ref string `json:"ref,omitempty"`
+		*ComponentData
+	}
+
+	// MLParameter is an input or output format of a model.
+	MLParameter struct {
+		Format string `json:"format,omitempty"`
+	}
+
+	// Considerations are the intended uses and limitations of a model.
+	Considerations struct {
+		Users                       []string        `json:"users,omitempty"`
+		UseCases                    []string        `json:"useCases,omitempty"`
+		TechnicalLimitations        []string        `json:"technicalLimitations,omitempty"`
+		PerformanceTradeoffs        []string        `json:"performanceTradeoffs,omitempty"`
+		EthicalConsiderations       json.RawMessage `json:"ethicalConsiderations,omitempty"`
+		EnvironmentalConsiderations json.RawMessage `json:"environmentalConsiderations,omitempty"`
+		FairnessAssessments         json.RawMessage `json:"fairnessAssessments,omitempty"`
+	}
+
+	// ComponentData describes the data of a data component.
+	ComponentData struct {
+		BOMRef   string        `json:"bom-ref,omitempty"`
+		Type     string        `json:"type"`
+		Name     string        `json:"name,omitempty"`
+		Contents *DataContents `json:"contents,omitempty"`
+	}
+
+	// DataContents locates the data.
+	DataContents struct {
+		URL string `json:"url,omitempty"`
+	}
+
+	// Property is a name and value pair.
+	Property struct {
+		Name  string `json:"name"`
+		Value string `json:"value,omitempty"`
+	}
+
+	// Dependency lists the components a component depends on.
+	Dependency struct {
+		Ref       string   `json:"ref"`
+		DependsOn []string `json:"dependsOn,omitempty"`
+	}
+)
+
+// PropertyPrefix prefixes the names of the properties holding the config fields that have no
+// CycloneDX equivalent, such as "modelpack:config.paramSize".
+const PropertyPrefix = "modelpack:config."
+
+// cycloneDXHashes maps the digest algorithms to the CycloneDX hash algorithms.
+var cycloneDXHashes = map[digest.Algorithm]string{
+	digest.SHA256: "SHA-256",
+	digest.SHA384: "SHA-384",
+	digest.SHA512: "SHA-512",
+}
+
+func cycloneDXHash(d digest.Digest) []Hash {
+	if d.Validate() != nil {
+		return nil
+	}
+	if alg, ok := cycloneDXHashes[d.Algorithm()]; ok {
+		return []Hash{{Algorithm: alg, Content: d.Encoded()}}
+	}
+	return nil
+}
+
+// CycloneDX returns a CycloneDX 1.6 ML-BOM of the model. The model is the machine-learning-model
+// component of the metadata, with its model card, licenses, authors, lineage as pedigree ancestors
+// and other config fields as properties. Its layers are file components and its datasets data components,
+// on which it depends. The document is validated before being returned.
+func CycloneDX(model v1.Model, manifest v1.ModelManifest, opts Options) ([]byte, error) {
+	d := model.Descriptor
+	c := model.Config
+	name := d.Name
+	if name == "" {
+		name = d.Title
+	}
+	if name == "" {
+		return nil, fmt.Errorf("cannot export a model without name or title")
+	}
+
+	m := Component{
+		Type:        "machine-learning-model",
+		BOMRef:      "model",
+		Name:        name,
+		Version:     d.Version,
+		Description: d.Description,
+		Hashes:      cycloneDXHash(opts.Digest),
+	}
+	if d.Vendor != "" {
+		m.Supplier = &OrganizationalEntity{Name: d.Vendor}
+	}
+	for _, author := range d.Authors {
+		m.Authors = append(m.Authors, OrganizationalContact{Name: author})
+	}
+	if len(d.Licenses) > 0 {
+		m.Licenses = []LicenseChoice{{Expression: licenseExpression(d.Licenses)}}
+	}
+	for _, ref := range []struct{ typ, url string }{{"documentation", d.DocURL}, {"vcs", d.SourceURL}} {
+		if ref.url != "" {
+			m.ExternalReferences = append(m.ExternalReferences, ExternalReference{Type: ref.typ, URL: ref.url})
+		}
+	}
+	if d.Lineage != nil {
+		m.Pedigree = &Pedigree{}
+		for _, p := range d.Lineage.Parents {
+			ancestor := Component{
+				Type:       "machine-learning-model",
+				Name:       p.Reference,
+				Hashes:     cycloneDXHash(p.Digest),
+				Properties: []Property{{Name: "modelpack:relationship", Value: string(p.Relationship)}},
+			}
+			if ancestor.Name == "" {
+				ancestor.Name = p.Digest.String()
+			}
+			m.Pedigree.Ancestors = append(m.Pedigree.Ancestors, ancestor)
+		}
+	}
+
+	params := &ModelParameters{ArchitectureFamily: c.Architecture, ModelArchitecture: d.Family}
+	if caps := c.Capabilities; caps != nil {
+		for _, t := range caps.InputTypes {
+			params.Inputs = append(params.Inputs, MLParameter{Format: string(t)})
+		}
+		for _, t := range caps.OutputTypes {
+			params.Outputs = append(params.Outputs, MLParameter{Format: string(t)})
+		}
+	}
+	var components []Component
+	dependency := Dependency{Ref: m.BOMRef}
+	for i, url := range d.DatasetsURL {
+		ref := fmt.Sprintf("dataset-%d", i)
+		components = append(components, Component{
+			Type:   "data",
+			BOMRef: ref,
+			Name:   url,
+			Data:   []ComponentData{{Type: "dataset", Name: url, Contents: &DataContents{URL: url}}},
+		})
+		params.Datasets = append(params.Datasets, DatasetChoice{Ref: ref})
+		dependency.DependsOn = append(dependency.DependsOn, ref)
+	}
+	m.ModelCard = &ModelCard{ModelParameters: params}
+
+	properties, err := configProperties(c, "architecture", "capabilities.inputTypes", "capabilities.outputTypes")
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range properties {
+		m.Properties = append(m.Properties, Property{Name: PropertyPrefix + p[0], Value: p[1]})
+	}
+
+	for i, layer := range manifest.Layers {
+		ref := fmt.Sprintf("layer-%d", i)
+		components = append(components, Component{
+			Type:     "file",
+			MimeType: layer.MediaType,
+			BOMRef:   ref,
+			Name:     layerPath(layer),
+			Hashes:   cycloneDXHash(layer.Digest),
+		})
+		dependency.DependsOn = append(dependency.DependsOn, ref)
+	}
+
+	bom := BOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.6",
+		SerialNumber: "urn:uuid:" + uuid(manifest),
+		Version:      1,
+		Metadata: &Metadata{
+			Timestamp: opts.created(model).Format(time.RFC3339),
+			Tools:     &Tools{Components: []Component{{Type: "application", Name: opts.creator()}}},
+			Component: &m,
+		},
+		Components:   components,
+		Dependencies: []Dependency{dependency},
+	}
+	doc, err := json.MarshalIndent(bom, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateCycloneDX(doc); err != nil {
+		return nil, fmt.Errorf("generated CycloneDX document is invalid: %w", err)
+	}
+	return doc, nil
+}
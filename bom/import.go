@@ -0,0 +1,515 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+)
+
+// ImportResult is a model converted from a CycloneDX machine-learning-model component.
+type ImportResult struct {
+	Model v1.Model
+
+	// Unmapped lists the information of the component that the model cannot represent, as the JSON
+	// path of the fields, such as "modelCard.considerations", or path=value for skipped values.
+	Unmapped []string
+}
+
+// modelComponentType is the CycloneDX component type of models.
+const modelComponentType = "machine-learning-model"
+
+// modalities lists the modalities accepted as model card input and output formats.
+var modalities = map[string]v1.Modality{
+	string(v1.TextModality):      v1.TextModality,
+	string(v1.ImageModality):     v1.ImageModality,
+	string(v1.AudioModality):     v1.AudioModality,
+	string(v1.VideoModality):     v1.VideoModality,
+	string(v1.EmbeddingModality): v1.EmbeddingModality,
+	string(v1.OtherModality):     v1.OtherModality,
+}
+
+var languageRegexp = regexp.MustCompile(`^[a-z]{2}$`)
+
+// ImportCycloneDX converts the model of a CycloneDX BOM: the metadata component when it is a
+// machine-learning-model, or else the first machine-learning-model component. The references of
+// its model card to data components are resolved to their URL. The document is validated first.
+func ImportCycloneDX(doc []byte) (*ImportResult, error) {
+	if err := ValidateCycloneDX(doc); err != nil {
+		return nil, err
+	}
+	var raw struct {
+		Metadata struct {
+			Component json.RawMessage `json:"component"`
+		} `json:"metadata"`
+		Components []json.RawMessage `json:"components"`
+	}
+	if err := json.Unmarshal(doc, &raw); err != nil {
+		return nil, err
+	}
+
+	candidates := raw.Components
+	if raw.Metadata.Component != nil {
+		candidates = append([]json.RawMessage{raw.Metadata.Component}, candidates...)
+	}
+	imp := &importer{data: make(map[string]Component)}
+	var model json.RawMessage
+	for _, candidate := range candidates {
+		var c Component
+		if err := json.Unmarshal(candidate, &c); err != nil {
+			return nil, err
+		}
+		if c.Type == modelComponentType && model == nil {
+			model = candidate
+		}
+		if c.Type == "data" && c.BOMRef != "" {
+			imp.data[c.BOMRef] = c
+		}
+	}
+	if model == nil {
+		return nil, errors.New("no machine-learning-model component")
+	}
+	return imp.component(model)
+}
+
+// ImportComponent converts a CycloneDX machine-learning-model component, which is validated first.
+// The references of its model card to data components are reported as unmapped.
+func ImportComponent(component []byte) (*ImportResult, error) {
+	doc := fmt.Sprintf(`{"bomFormat": "CycloneDX", "specVersion": "1.6", "components": [%s]}`, component)
+	if err := ValidateCycloneDX([]byte(doc)); err != nil {
+		return nil, err
+	}
+	var c Component
+	if err := json.Unmarshal(component, &c); err != nil {
+		return nil, err
+	}
+	if c.Type != modelComponentType {
+		return nil, fmt.Errorf("component type is %q, expected %s", c.Type, modelComponentType)
+	}
+	return (&importer{}).component(component)
+}
+
+type importer struct {
+	// data maps the bom-ref of the data components to the component.
+	data map[string]Component
+
+	result ImportResult
+}
+
+func (imp *importer) unmapped(path string) {
+	imp.result.Unmapped = append(imp.result.Unmapped, path)
+}
+
+// unmappedKeys reports the keys of a JSON object that are not in mapped.
+func (imp *importer) unmappedKeys(raw json.RawMessage, prefix string, mapped ...string) {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(raw, &fields) != nil {
+		return
+	}
+	for key := range fields {
+		if !slices.Contains(mapped, key) {
+			imp.unmapped(prefix + key)
+		}
+	}
+}
+
+// splitExpression splits a conjunction of parenthesized license expressions, as combined by
+// licenseExpression, and returns the other expressions as they are.
+func splitExpression(expression string) []string {
+	if !strings.HasPrefix(expression, "(") || !strings.HasSuffix(expression, ")") {
+		return []string{expression}
+	}
+	parts := strings.Split(expression[1:len(expression)-1], ") AND (")
+	for _, part := range parts {
+		if strings.ContainsAny(part, "()") {
+			return []string{expression}
+		}
+	}
+	return parts
+}
+
+func (imp *importer) component(raw json.RawMessage) (*ImportResult, error) {
+	var c Component
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, err
+	}
+	imp.unmappedKeys(raw, "", "type", "bom-ref", "name", "version", "description", "supplier", "authors",
+		"licenses", "externalReferences", "pedigree", "modelCard", "properties")
+
+	d := &imp.result.Model.Descriptor
+	d.Name = c.Name
+	d.Version = c.Version
+	d.Description = c.Description
+	if c.Supplier != nil {
+		d.Vendor = c.Supplier.Name
+		if len(c.Supplier.URL) > 0 {
+			imp.unmapped("supplier.url")
+		}
+	}
+	for _, author := range c.Authors {
+		switch {
+		case author.Name != "" && author.Email != "":
+			d.Authors = append(d.Authors, author.Name+" <"+author.Email+">")
+		case author.Name != "":
+			d.Authors = append(d.Authors, author.Name)
+		default:
+			d.Authors = append(d.Authors, author.Email)
+		}
+	}
+	for _, l := range c.Licenses {
+		switch {
+		case l.Expression != "":
+			d.Licenses = append(d.Licenses, splitExpression(l.Expression)...)
+		case l.License != nil && l.License.ID != "":
+			d.Licenses = append(d.Licenses, l.License.ID)
+		case l.License != nil:
+			imp.unmapped("licenses.license.name=" + l.License.Name)
+		}
+	}
+	for _, ref := range c.ExternalReferences {
+		switch {
+		case ref.Type == "documentation" && d.DocURL == "":
+			d.DocURL = ref.URL
+		case ref.Type == "vcs" && d.SourceURL == "":
+			d.SourceURL = ref.URL
+		default:
+			imp.unmapped("externalReferences." + ref.Type + "=" + ref.URL)
+		}
+	}
+	imp.pedigree(raw, c.Pedigree)
+	imp.properties(c.Properties)
+	imp.consistent(c.Properties)
+	if c.ModelCard != nil {
+		var card struct {
+			ModelCard json.RawMessage `json:"modelCard"`
+		}
+		if err := json.Unmarshal(raw, &card); err != nil {
+			return nil, err
+		}
+		imp.modelCard(card.ModelCard, c.ModelCard)
+	}
+
+	sort.Strings(imp.result.Unmapped)
+	return &imp.result, nil
+}
+
+// pedigree maps the ancestors to the parents of the lineage, with the relationship of their
+// modelpack:relationship property. The ancestors without a known relationship are reported.
+func (imp *importer) pedigree(raw json.RawMessage, p *Pedigree) {
+	if p == nil {
+		return
+	}
+	var pedigree struct {
+		Pedigree json.RawMessage `json:"pedigree"`
+	}
+	if json.Unmarshal(raw, &pedigree) == nil {
+		imp.unmappedKeys(pedigree.Pedigree, "pedigree.", "ancestors")
+	}
+	if len(p.Ancestors) == 0 {
+		return
+	}
+
+	lineage := &v1.ModelLineage{}
+	for _, a := range p.Ancestors {
+		parent := v1.ParentModel{Reference: a.Name}
+		for _, h := range a.Hashes {
+			for alg, cdx := range cycloneDXHashes {
+				if h.Algorithm == cdx && parent.Digest == "" {
+					parent.Digest = digest.NewDigestFromEncoded(alg, h.Content)
+				}
+			}
+		}
+		if parent.Digest.String() == parent.Reference {
+			parent.Reference = ""
+		}
+		for _, prop := range a.Properties {
+			if prop.Name == "modelpack:relationship" {
+				parent.Relationship = v1.Relationship(prop.Value)
+			} else {
+				imp.unmapped("pedigree.ancestors.properties." + prop.Name)
+			}
+		}
+		if (&v1.ModelLineage{Parents: []v1.ParentModel{parent}}).Validate() != nil {
+			if parent.Relationship != "" {
+				imp.unmapped("pedigree.ancestors.properties.modelpack:relationship=" + string(parent.Relationship))
+			}
+			imp.unmapped("pedigree.ancestors=" + a.Name)
+			continue
+		}
+		lineage.Parents = append(lineage.Parents, parent)
+	}
+	if len(lineage.Parents) > 0 {
+		imp.result.Model.Descriptor.Lineage = lineage
+	}
+}
+
+// properties maps the modelpack:config properties back to the fields of the config, parsing their
+// value according to the type of the field. The list items are separated by commas, except for the
+// lists of objects which are JSON arrays.
+func (imp *importer) properties(properties []Property) {
+	fields := make(map[string]any)
+	for _, p := range properties {
+		path, ok := strings.CutPrefix(p.Name, PropertyPrefix)
+		if !ok {
+			imp.unmapped("properties." + p.Name)
+			continue
+		}
+		keys := strings.Split(path, ".")
+		t, ok := fieldType(reflect.TypeOf(v1.ModelConfig{}), keys)
+		if !ok {
+			imp.unmapped("properties." + p.Name)
+			continue
+		}
+		value, err := parseProperty(t, p.Value)
+		switch path {
+		case "capabilities.languages":
+			value, err = imp.languages(p.Name, p.Value), nil
+		case "capabilities.inputTypes", "capabilities.outputTypes":
+			value, err = imp.modalities(p.Name, p.Value), nil
+		}
+		if err != nil {
+			imp.unmapped("properties." + p.Name + "=" + p.Value)
+			continue
+		}
+		object := fields
+		for _, key := range keys[:len(keys)-1] {
+			if _, ok := object[key].(map[string]any); !ok {
+				object[key] = make(map[string]any)
+			}
+			object = object[key].(map[string]any)
+		}
+		object[keys[len(keys)-1]] = value
+	}
+	if len(fields) == 0 {
+		return
+	}
+	// the values match the types of the fields, so decoding them cannot fail
+	buf, _ := json.Marshal(fields)
+	_ = json.Unmarshal(buf, &imp.result.Model.Config)
+}
+
+// languages returns the ISO 639-1 codes of a list of languages, reporting the other ones.
+func (imp *importer) languages(name, value string) []string {
+	var languages []string
+	for _, lang := range strings.Split(value, ",") {
+		if lang = strings.TrimSpace(lang); languageRegexp.MatchString(lang) {
+			languages = append(languages, lang)
+		} else {
+			imp.unmapped("properties." + name + "=" + lang)
+		}
+	}
+	return languages
+}
+
+// modalities returns the known modalities of a list, reporting the other ones.
+func (imp *importer) modalities(name, value string) []string {
+	var known []string
+	for _, m := range strings.Split(value, ",") {
+		if m = strings.TrimSpace(m); modalities[m] == "" {
+			imp.unmapped("properties." + name + "=" + m)
+		} else if !slices.Contains(known, m) {
+			known = append(known, m)
+		}
+	}
+	return known
+}
+
+// consistent drops the sections of the config that are inconsistent, on their own or with the
+// paramSize, precision and quantization of the config, and reports their properties.
+func (imp *importer) consistent(properties []Property) {
+	c := &imp.result.Model.Config
+	base := v1.ModelConfig{ParamSize: c.ParamSize, Precision: c.Precision, Quantization: c.Quantization}
+	architecture, quantization, runtime, hardware, adapter := base, base, base, base, base
+	architecture.ArchitectureDetails = c.ArchitectureDetails
+	quantization.QuantizationDetails = c.QuantizationDetails
+	runtime.Runtime = c.Runtime
+	hardware.Hardware = c.Hardware
+	adapter.Adapter = c.Adapter
+	for _, section := range []struct {
+		name   string
+		config v1.ModelConfig
+		drop   func()
+	}{
+		{"architectureDetails", architecture, func() { c.ArchitectureDetails = nil }},
+		{"quantizationDetails", quantization, func() { c.QuantizationDetails = nil }},
+		{"runtime", runtime, func() { c.Runtime = nil }},
+		{"hardware", hardware, func() { c.Hardware = nil }},
+		{"adapter", adapter, func() { c.Adapter = nil }},
+	} {
+		if section.config.Validate() == nil {
+			continue
+		}
+		section.drop()
+		for _, p := range properties {
+			if strings.HasPrefix(p.Name, PropertyPrefix+section.name+".") {
+				imp.unmapped("properties." + p.Name + "=" + p.Value)
+			}
+		}
+	}
+}
+
+// fieldType returns the type of the field of a struct at a JSON path.
+func fieldType(t reflect.Type, keys []string) (reflect.Type, bool) {
+	for _, key := range keys {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+			return nil, false
+		}
+		found := false
+		for i := 0; i < t.NumField(); i++ {
+			if name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ","); name == key {
+				t, found = t.Field(i).Type, true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t, true
+}
+
+// parseProperty parses the value of a property as the JSON value of a field of type t.
+func parseProperty(t reflect.Type, s string) (any, error) {
+	if t == reflect.TypeOf(time.Time{}) {
+		_, err := time.Parse(time.RFC3339, s)
+		return s, err
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return s, nil
+	case reflect.Bool:
+		return strconv.ParseBool(s)
+	case reflect.Int, reflect.Int64:
+		return strconv.ParseInt(s, 10, 64)
+	case reflect.Float64:
+		return strconv.ParseFloat(s, 64)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			items := strings.Split(s, ",")
+			for i := range items {
+				items[i] = strings.TrimSpace(items[i])
+			}
+			return items, nil
+		}
+		var items []any
+		if err := json.Unmarshal([]byte(s), &items); err != nil {
+			return nil, err
+		}
+		// check the items against the type of the field
+		v := reflect.New(t)
+		return items, json.Unmarshal([]byte(s), v.Interface())
+	default:
+		return nil, fmt.Errorf("unsupported field type %s", t)
+	}
+}
+
+func (imp *importer) capabilities() *v1.ModelCapabilities {
+	c := &imp.result.Model.Config
+	if c.Capabilities == nil {
+		c.Capabilities = &v1.ModelCapabilities{}
+	}
+	return c.Capabilities
+}
+
+// modelCard maps the architecture, the input and output formats and the datasets of the model parameters.
+// The formats are added to the modalities set from the properties, without duplicates.
+func (imp *importer) modelCard(raw json.RawMessage, card *ModelCard) {
+	imp.unmappedKeys(raw, "modelCard.", "bom-ref", "modelParameters")
+	p := card.ModelParameters
+	if p == nil {
+		return
+	}
+	var params struct {
+		ModelParameters json.RawMessage `json:"modelParameters"`
+	}
+	if json.Unmarshal(raw, &params) == nil {
+		imp.unmappedKeys(params.ModelParameters, "modelCard.modelParameters.",
+			"architectureFamily", "modelArchitecture", "inputs", "outputs", "datasets")
+	}
+
+	imp.result.Model.Config.Architecture = p.ArchitectureFamily
+	imp.result.Model.Descriptor.Family = p.ModelArchitecture
+	for _, in := range p.Inputs {
+		if m, ok := modalities[strings.ToLower(in.Format)]; ok {
+			if caps := imp.capabilities(); !slices.Contains(caps.InputTypes, m) {
+				caps.InputTypes = append(caps.InputTypes, m)
+			}
+		} else {
+			imp.unmapped("modelCard.modelParameters.inputs.format=" + in.Format)
+		}
+	}
+	for _, out := range p.Outputs {
+		if m, ok := modalities[strings.ToLower(out.Format)]; ok {
+			if caps := imp.capabilities(); !slices.Contains(caps.OutputTypes, m) {
+				caps.OutputTypes = append(caps.OutputTypes, m)
+			}
+		} else {
+			imp.unmapped("modelCard.modelParameters.outputs.format=" + out.Format)
+		}
+	}
+
+	d := &imp.result.Model.Descriptor
+	for _, dataset := range p.Datasets {
+		if dataset.ComponentData != nil {
+			if dataset.Contents != nil && dataset.Contents.URL != "" {
+				d.DatasetsURL = append(d.DatasetsURL, dataset.Contents.URL)
+			} else {
+				imp.unmapped("modelCard.modelParameters.datasets.name=" + dataset.Name)
+			}
+			continue
+		}
+		if url := imp.dataURL(dataset.Ref); url != "" {
+			d.DatasetsURL = append(d.DatasetsURL, url)
+		} else {
+			imp.unmapped("modelCard.modelParameters.datasets.ref=" + dataset.Ref)
+		}
+	}
+}
+
+// dataURL returns the URL of the contents of a data component, or of its first external reference.
+func (imp *importer) dataURL(ref string) string {
+	c, ok := imp.data[ref]
+	if !ok {
+		return ""
+	}
+	for _, data := range c.Data {
+		if data.Contents != nil && data.Contents.URL != "" {
+			return data.Contents.URL
+		}
+	}
+	if len(c.ExternalReferences) > 0 {
+		return c.ExternalReferences[0].URL
+	}
+	return ""
+}
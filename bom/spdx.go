@@ -0,0 +1,344 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bom
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+	spdx "github.com/spdx/tools-golang/spdx/v3/v3_0"
+)
+
+// SPDXContext is the JSON-LD context of SPDX 3.0.1 documents.
+const SPDXContext = "https://spdx.org/rdf/3.0.1/spdx-context.jsonld"
+
+// spdxNoAssertion is the SPDX individual stating that no assertion is made about a value.
+const spdxNoAssertion = "https://spdx.org/rdf/3.0.1/terms/Core/NoAssertionElement"
+
+// aiPackageProperties are the properties the AI profile requires on every ai_AIPackage.
+var aiPackageProperties = []string{
+	"suppliedBy",
+	"releaseTime",
+	"software_downloadLocation",
+	"software_packageVersion",
+	"software_primaryPurpose",
+}
+
+// ValidateSPDX validates a document against the SPDX 3.0.1 model, and checks that its AI packages
+// have the properties required by the AI profile.
+func ValidateSPDX(doc []byte) error {
+	var d struct {
+		Graph []map[string]any `json:"@graph"`
+	}
+	if err := json.Unmarshal(doc, &d); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	var m spdx.Document
+	if err := m.FromJSON(bytes.NewReader(doc)); err != nil {
+		return fmt.Errorf("invalid document: %w", err)
+	}
+	if err := m.Validate(false); err != nil {
+		return fmt.Errorf("invalid document: %w", err)
+	}
+	var errs []error
+	for i, n := range d.Graph {
+		if n["type"] != "ai_AIPackage" {
+			continue
+		}
+		for _, p := range aiPackageProperties {
+			if _, ok := n[p]; !ok {
+				errs = append(errs, fmt.Errorf("@graph/%d: ai_AIPackage without %s", i, p))
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid document: %w", err)
+	}
+	return nil
+}
+
+// spdxNode is an object of the @graph of an SPDX document, holding the properties of every exported class.
+type spdxNode struct {
+	Type         string `json:"type"`
+	ID           string `json:"@id,omitempty"`
+	SpdxID       string `json:"spdxId,omitempty"`
+	CreationInfo string `json:"creationInfo,omitempty"`
+
+	// CreationInfo
+	SpecVersion string   `json:"specVersion,omitempty"`
+	Created     string   `json:"created,omitempty"`
+	CreatedBy   []string `json:"createdBy,omitempty"`
+
+	// Element
+	Name          string     `json:"name,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	Comment       string     `json:"comment,omitempty"`
+	VerifiedUsing []spdxHash `json:"verifiedUsing,omitempty"`
+
+	// SpdxDocument
+	ProfileConformance []string `json:"profileConformance,omitempty"`
+	RootElement        []string `json:"rootElement,omitempty"`
+	Element            []string `json:"element,omitempty"`
+
+	// Artifact, Package and File
+	ReleaseTime      string   `json:"releaseTime,omitempty"`
+	SuppliedBy       string   `json:"suppliedBy,omitempty"`
+	OriginatedBy     []string `json:"originatedBy,omitempty"`
+	PrimaryPurpose   string   `json:"software_primaryPurpose,omitempty"`
+	PackageVersion   string   `json:"software_packageVersion,omitempty"`
+	DownloadLocation string   `json:"software_downloadLocation,omitempty"`
+	HomePage         string   `json:"software_homePage,omitempty"`
+	SourceInfo       string   `json:"software_sourceInfo,omitempty"`
+	ContentType      string   `json:"contentType,omitempty"`
+	FileKind         string   `json:"software_fileKind,omitempty"`
+
+	// AIPackage and DatasetPackage
+	TypeOfModel                 []string              `json:"ai_typeOfModel,omitempty"`
+	Hyperparameter              []spdxDictionaryEntry `json:"ai_hyperparameter,omitempty"`
+	InformationAboutApplication string                `json:"ai_informationAboutApplication,omitempty"`
+	DatasetType                 []string              `json:"dataset_datasetType,omitempty"`
+
+	// LicenseExpression
+	LicenseExpression string `json:"simplelicensing_licenseExpression,omitempty"`
+
+	// Relationship
+	From             string   `json:"from,omitempty"`
+	To               []string `json:"to,omitempty"`
+	RelationshipType string   `json:"relationshipType,omitempty"`
+}
+
+type spdxHash struct {
+	Type      string `json:"type"`
+	Algorithm string `json:"algorithm"`
+	HashValue string `json:"hashValue"`
+}
+
+type spdxDictionaryEntry struct {
+	Type  string `json:"type"`
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+type spdxDocument struct {
+	Context string     `json:"@context"`
+	Graph   []spdxNode `json:"@graph"`
+}
+
+// spdxHashes maps the digest algorithms to the SPDX hash algorithms.
+var spdxHashes = map[digest.Algorithm]string{
+	digest.SHA256: "sha256",
+	digest.SHA384: "sha384",
+	digest.SHA512: "sha512",
+}
+
+func spdxHashOf(d digest.Digest) []spdxHash {
+	if d.Validate() != nil {
+		return nil
+	}
+	if alg, ok := spdxHashes[d.Algorithm()]; ok {
+		return []spdxHash{{Type: "Hash", Algorithm: alg, HashValue: d.Encoded()}}
+	}
+	return nil
+}
+
+// primaryPurposes maps the layer components to the SPDX software purposes.
+var primaryPurposes = map[string]string{
+	v1.ComponentWeight:       "model",
+	v1.ComponentAdapter:      "model",
+	v1.ComponentWeightConfig: "configuration",
+	v1.ComponentDoc:          "documentation",
+	v1.ComponentCode:         "source",
+	v1.ComponentDataset:      "data",
+}
+
+// SPDX returns an SPDX 3.0.1 document of the model using the AI profile. The model is an ai_AIPackage,
+// the root element of the document, with its config fields as hyperparameters and its capabilities as
+// information about its application. It contains its layers as files, is trained on its datasets,
+// descends from its parent models and has its licenses as declared license. The document is validated
+// before being returned.
+//
+// The release time of the model is its creation time, or the creation time of the document. Its unknown
+// supplier, version and download location are stated as no assertion. The parent models are software
+// packages, as their release time is not known.
+func SPDX(model v1.Model, manifest v1.ModelManifest, opts Options) ([]byte, error) {
+	d := model.Descriptor
+	c := model.Config
+	name := d.Name
+	if name == "" {
+		name = d.Title
+	}
+	if name == "" {
+		return nil, fmt.Errorf("cannot export a model without name or title")
+	}
+
+	namespace := "urn:uuid:" + uuid(manifest) + "#"
+	const creationInfo = "_:creationinfo"
+	var graph []spdxNode
+	var elements []string
+	add := func(n spdxNode) string {
+		n.SpdxID = namespace + n.SpdxID
+		n.CreationInfo = creationInfo
+		graph = append(graph, n)
+		elements = append(elements, n.SpdxID)
+		return n.SpdxID
+	}
+	relate := func(from, relationship string, to []string, comment string) {
+		if len(to) > 0 {
+			add(spdxNode{
+				Type:             "Relationship",
+				SpdxID:           fmt.Sprintf("SPDXRef-Relationship-%d", len(graph)),
+				From:             from,
+				To:               to,
+				RelationshipType: relationship,
+				Comment:          comment,
+			})
+		}
+	}
+
+	creator := add(spdxNode{Type: "Agent", SpdxID: "SPDXRef-Creator", Name: opts.creator()})
+	m := spdxNode{
+		Type:             "ai_AIPackage",
+		SpdxID:           "SPDXRef-Model",
+		Name:             name,
+		Description:      d.Description,
+		VerifiedUsing:    spdxHashOf(opts.Digest),
+		ReleaseTime:      opts.created(model).Format(time.RFC3339),
+		PrimaryPurpose:   "model",
+		PackageVersion:   d.Version,
+		DownloadLocation: spdxNoAssertion,
+		HomePage:         d.DocURL,
+		SourceInfo:       d.SourceURL,
+	}
+	if d.CreatedAt != nil {
+		m.ReleaseTime = d.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	if m.PackageVersion == "" {
+		m.PackageVersion = "NOASSERTION"
+	}
+	supplier := spdxNode{Type: "Agent", SpdxID: "SPDXRef-Vendor", Name: d.Vendor}
+	if d.Vendor == "" {
+		supplier.Name = "NOASSERTION"
+		supplier.Comment = "The supplier of the model is not known."
+	}
+	m.SuppliedBy = add(supplier)
+	for i, author := range d.Authors {
+		m.OriginatedBy = append(m.OriginatedBy, add(spdxNode{Type: "Agent", SpdxID: fmt.Sprintf("SPDXRef-Author-%d", i), Name: author}))
+	}
+	if c.Architecture != "" {
+		m.TypeOfModel = []string{c.Architecture}
+	}
+	hyperparameters, err := configProperties(c, "capabilities")
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range hyperparameters {
+		m.Hyperparameter = append(m.Hyperparameter, spdxDictionaryEntry{Type: "DictionaryEntry", Key: p[0], Value: p[1]})
+	}
+	capabilities, err := configProperties(v1.ModelConfig{Capabilities: c.Capabilities})
+	if err != nil {
+		return nil, err
+	}
+	var info []string
+	for _, p := range capabilities {
+		info = append(info, strings.TrimPrefix(p[0], "capabilities.")+": "+p[1])
+	}
+	m.InformationAboutApplication = strings.Join(info, "; ")
+	root := add(m)
+
+	if len(d.Licenses) > 0 {
+		license := add(spdxNode{Type: "simplelicensing_LicenseExpression", SpdxID: "SPDXRef-License", LicenseExpression: licenseExpression(d.Licenses)})
+		relate(root, "hasDeclaredLicense", []string{license}, "")
+	}
+
+	var datasets []string
+	for i, url := range d.DatasetsURL {
+		datasets = append(datasets, add(spdxNode{
+			Type:             "dataset_DatasetPackage",
+			SpdxID:           fmt.Sprintf("SPDXRef-Dataset-%d", i),
+			Name:             url,
+			PrimaryPurpose:   "data",
+			DownloadLocation: url,
+			DatasetType:      []string{"noAssertion"},
+		}))
+	}
+	relate(root, "trainedOn", datasets, "")
+
+	var files []string
+	for i, layer := range manifest.Layers {
+		purpose := "other"
+		if mt, err := v1.ParseLayerMediaType(layer.MediaType); err == nil {
+			purpose = primaryPurposes[mt.Component]
+		}
+		files = append(files, add(spdxNode{
+			Type:           "software_File",
+			SpdxID:         fmt.Sprintf("SPDXRef-Layer-%d", i),
+			Name:           layerPath(layer),
+			VerifiedUsing:  spdxHashOf(layer.Digest),
+			PrimaryPurpose: purpose,
+			ContentType:    layer.MediaType,
+			FileKind:       "file",
+		}))
+	}
+	relate(root, "contains", files, "")
+
+	if d.Lineage != nil {
+		for i, p := range d.Lineage.Parents {
+			parent := spdxNode{
+				Type:           "software_Package",
+				SpdxID:         fmt.Sprintf("SPDXRef-Parent-%d", i),
+				Name:           p.Reference,
+				VerifiedUsing:  spdxHashOf(p.Digest),
+				PrimaryPurpose: "model",
+			}
+			if parent.Name == "" {
+				parent.Name = p.Digest.String()
+			}
+			relate(root, "descendantOf", []string{add(parent)}, string(p.Relationship))
+		}
+	}
+
+	document := spdxNode{
+		Type:               "SpdxDocument",
+		SpdxID:             namespace + "SPDXRef-Document",
+		CreationInfo:       creationInfo,
+		Name:               name,
+		ProfileConformance: []string{"core", "software", "ai", "dataset", "simpleLicensing"},
+		RootElement:        []string{root},
+		Element:            elements,
+	}
+	creation := spdxNode{
+		Type:        "CreationInfo",
+		ID:          creationInfo,
+		SpecVersion: "3.0.1",
+		Created:     opts.created(model).Format(time.RFC3339),
+		CreatedBy:   []string{creator},
+	}
+	doc, err := json.MarshalIndent(spdxDocument{Context: SPDXContext, Graph: append([]spdxNode{creation, document}, graph...)}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateSPDX(doc); err != nil {
+		return nil, fmt.Errorf("generated SPDX document is invalid: %w", err)
+	}
+	return doc, nil
+}
@@ -0,0 +1,139 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package classify
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+// Rule maps the files matching a pattern to a layer component.
+type Rule struct {
+	// Pattern is a path.Match pattern, matched case-insensitively against the base name of the file,
+	// or against the whole slash separated path when the pattern contains a slash.
+	Pattern string
+
+	// Component is the layer component of the matching files, such as v1.ComponentWeight.
+	Component string
+
+	// Untested marks the rule as ambiguous, the files it matches should be annotated
+	// with `org.cncf.model.file.mediatype.untested` set to "true".
+	Untested bool
+}
+
+// DefaultRules is the built-in rule table, evaluated in order.
+var DefaultRules = []Rule{
+	// adapters, as saved by PEFT
+	{Pattern: "adapter_model.*", Component: v1.ComponentAdapter},
+	{Pattern: "adapter_config.json", Component: v1.ComponentAdapter},
+
+	// model weights
+	{Pattern: "*.safetensors", Component: v1.ComponentWeight},
+	{Pattern: "*.gguf", Component: v1.ComponentWeight},
+	{Pattern: "*.onnx", Component: v1.ComponentWeight},
+	{Pattern: "*.pt", Component: v1.ComponentWeight},
+	{Pattern: "*.pth", Component: v1.ComponentWeight},
+	{Pattern: "*.bin", Component: v1.ComponentWeight, Untested: true},
+
+	// configuration of the model weights
+	{Pattern: "config.json", Component: v1.ComponentWeightConfig},
+	{Pattern: "generation_config.json", Component: v1.ComponentWeightConfig},
+	{Pattern: "tokenizer.*", Component: v1.ComponentWeightConfig},
+	{Pattern: "tokenizer_config.json", Component: v1.ComponentWeightConfig},
+	{Pattern: "special_tokens_map.json", Component: v1.ComponentWeightConfig},
+	{Pattern: "vocab.*", Component: v1.ComponentWeightConfig},
+	{Pattern: "merges.txt", Component: v1.ComponentWeightConfig},
+	{Pattern: "*.safetensors.index.json", Component: v1.ComponentWeightConfig},
+
+	// documentation
+	{Pattern: "readme*", Component: v1.ComponentDoc},
+	{Pattern: "license*", Component: v1.ComponentDoc},
+	{Pattern: "*.md", Component: v1.ComponentDoc, Untested: true},
+
+	// code
+	{Pattern: "*.py", Component: v1.ComponentCode},
+	{Pattern: "*.sh", Component: v1.ComponentCode, Untested: true},
+
+	// datasets
+	{Pattern: "*.parquet", Component: v1.ComponentDataset},
+	{Pattern: "*.jsonl", Component: v1.ComponentDataset},
+	{Pattern: "*.arrow", Component: v1.ComponentDataset, Untested: true},
+}
+
+// Result is the classification of a file.
+type Result struct {
+	// Component is the layer component of the file, such as v1.ComponentWeight.
+	Component string
+
+	// Untested reports whether the classification is a guess, either because the
+	// matching rule is ambiguous or because no rule matched the file.
+	Untested bool
+}
+
+// MediaType returns the layer media type of the file for the given packaging,
+// compression is ignored unless archived is set.
+func (r Result) MediaType(archived bool, compression string) string {
+	mt := v1.LayerMediaType{Component: r.Component, Archived: archived}
+	if archived {
+		mt.Compression = compression
+	}
+	return mt.String()
+}
+
+// Annotations returns the layer annotations recording whether the classification is untested.
+func (r Result) Annotations() map[string]string {
+	return map[string]string{
+		v1.AnnotationMediaTypeUntested: strconv.FormatBool(r.Untested),
+	}
+}
+
+// Classifier maps model files to layer components.
+type Classifier struct {
+	rules []Rule
+
+	// Fallback is the component of files matching no rule, v1.ComponentCode by default.
+	Fallback string
+}
+
+// New returns a Classifier evaluating the given rules before DefaultRules,
+// so that they override the built-in classification.
+func New(rules ...Rule) *Classifier {
+	c := &Classifier{Fallback: v1.ComponentCode}
+	c.rules = append(c.rules, rules...)
+	c.rules = append(c.rules, DefaultRules...)
+	return c
+}
+
+// Classify returns the classification of the file at the slash separated path p.
+func (c *Classifier) Classify(p string) Result {
+	p = strings.ToLower(path.Clean(p))
+	base := path.Base(p)
+	for _, rule := range c.rules {
+		name := base
+		if strings.Contains(rule.Pattern, "/") {
+			name = p
+		}
+		// path.Match only fails on malformed patterns, which never match
+		if ok, _ := path.Match(strings.ToLower(rule.Pattern), name); ok {
+			return Result{Component: rule.Component, Untested: rule.Untested}
+		}
+	}
+	return Result{Component: c.Fallback, Untested: true}
+}
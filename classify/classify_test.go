@@ -0,0 +1,72 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package classify_test
+
+import (
+	"testing"
+
+	"github.com/modelpack/model-spec/classify"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+func TestClassify(t *testing.T) {
+	c := classify.New(
+		classify.Rule{Pattern: "eval/*.json", Component: v1.ComponentDataset},
+		classify.Rule{Pattern: "*.bin", Component: v1.ComponentWeight},
+	)
+
+	for _, tt := range []struct {
+		path      string
+		component string
+		untested  bool
+	}{
+		{path: "model-00001-of-00004.safetensors", component: v1.ComponentWeight},
+		{path: "weights/model.GGUF", component: v1.ComponentWeight},
+		{path: "model.onnx", component: v1.ComponentWeight},
+		{path: "pytorch_model.bin", component: v1.ComponentWeight},
+		{path: "config.json", component: v1.ComponentWeightConfig},
+		{path: "adapter_model.safetensors", component: v1.ComponentAdapter},
+		{path: "lora/adapter_config.json", component: v1.ComponentAdapter},
+		{path: "tokenizer.model", component: v1.ComponentWeightConfig},
+		{path: "generation_config.json", component: v1.ComponentWeightConfig},
+		{path: "README.md", component: v1.ComponentDoc},
+		{path: "LICENSE", component: v1.ComponentDoc},
+		{path: "docs/USAGE.md", component: v1.ComponentDoc, untested: true},
+		{path: "modeling_xyz.py", component: v1.ComponentCode},
+		{path: "train.parquet", component: v1.ComponentDataset},
+		{path: "eval/questions.json", component: v1.ComponentDataset},
+		{path: "questions.json", component: v1.ComponentCode, untested: true},
+	} {
+		got := c.Classify(tt.path)
+		if got.Component != tt.component || got.Untested != tt.untested {
+			t.Errorf("%s: expected %s (untested %t), got %s (untested %t)", tt.path, tt.component, tt.untested, got.Component, got.Untested)
+		}
+	}
+}
+
+func TestResultMediaType(t *testing.T) {
+	r := classify.New().Classify("model.safetensors")
+	if got := r.MediaType(false, ""); got != v1.MediaTypeModelWeightRaw {
+		t.Errorf("expected %s, got %s", v1.MediaTypeModelWeightRaw, got)
+	}
+	if got := r.MediaType(true, v1.CompressionZstd); got != v1.MediaTypeModelWeightZstd {
+		t.Errorf("expected %s, got %s", v1.MediaTypeModelWeightZstd, got)
+	}
+	if got := r.Annotations()[v1.AnnotationMediaTypeUntested]; got != "false" {
+		t.Errorf("expected untested annotation false, got %q", got)
+	}
+}
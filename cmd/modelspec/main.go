@@ -0,0 +1,48 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command modelspec provides tools to work with model artifacts.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `Usage: modelspec <command> [arguments]
+
+Commands:
+  verify    check the consistency of a model artifact in an OCI image layout
+`
+
+// commands maps the subcommand names to their implementation,
+// each receiving the remaining arguments and returning the exit code.
+var commands = map[string]func(args []string) int{
+	"verify": runVerify,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	os.Exit(cmd(os.Args[2:]))
+}
@@ -0,0 +1,151 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package content
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Layer holds the content addresses of a layer blob.
+type Layer struct {
+	// Descriptor references the blob as stored, with its media type, digest and size.
+	Descriptor ocispec.Descriptor
+
+	// DiffID is the digest of the uncompressed content of the blob.
+	DiffID digest.Digest
+}
+
+// ComputeLayer reads a layer blob of the given media type and returns its descriptor and DiffID in a single pass.
+// For raw and uncompressed tar layers the DiffID equals the blob digest.
+func ComputeLayer(r io.Reader, mediaType string) (Layer, error) {
+	mt, err := v1.ParseLayerMediaType(mediaType)
+	if err != nil {
+		return Layer{}, err
+	}
+
+	blob := digest.Canonical.Digester()
+	counter := &countWriter{}
+	tee := io.TeeReader(r, io.MultiWriter(blob.Hash(), counter))
+
+	diff := blob
+	if mt.Compression != "" {
+		diff = digest.Canonical.Digester()
+		zr, err := Decompress(tee, mt.Compression)
+		if err != nil {
+			return Layer{}, err
+		}
+		_, err = io.Copy(diff.Hash(), zr)
+		zr.Close()
+		if err != nil {
+			return Layer{}, fmt.Errorf("failed to decompress layer: %w", err)
+		}
+	}
+	// consume what the decompressor left, such as trailing padding, so the blob digest covers the whole input
+	if _, err := io.Copy(io.Discard, tee); err != nil {
+		return Layer{}, fmt.Errorf("failed to read layer: %w", err)
+	}
+
+	return Layer{
+		Descriptor: ocispec.Descriptor{
+			MediaType: mediaType,
+			Digest:    blob.Digest(),
+			Size:      counter.n,
+		},
+		DiffID: diff.Digest(),
+	}, nil
+}
+
+// Decompress returns a reader of the content of r decompressed with the given compression,
+// such as v1.CompressionGzip. An empty compression returns r unchanged.
+func Decompress(r io.Reader, compression string) (io.ReadCloser, error) {
+	switch compression {
+	case "":
+		return io.NopCloser(r), nil
+	case v1.CompressionGzip:
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzip stream: %w", err)
+		}
+		return zr, nil
+	case v1.CompressionZstd:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read zstd stream: %w", err)
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", compression)
+	}
+}
+
+// ModelFS returns the ModelFS of a model artifact made of the given layers, in order.
+func ModelFS(layers []Layer) v1.ModelFS {
+	fs := v1.ModelFS{Type: "layers", DiffIDs: make([]digest.Digest, 0, len(layers))}
+	for _, layer := range layers {
+		fs.DiffIDs = append(fs.DiffIDs, layer.DiffID)
+	}
+	return fs
+}
+
+// ConfigDescriptor marshals the model config and returns its descriptor with the
+// `application/vnd.cncf.model.config.v1+json` media type, along with the marshalled bytes to store as the blob.
+// The marshalling is canonical: struct fields are written in declaration order and map keys are sorted,
+// so equal configs always produce the same descriptor.
+func ConfigDescriptor(model v1.Model) (ocispec.Descriptor, []byte, error) {
+	buf, err := json.Marshal(model)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, fmt.Errorf("failed to marshal model config: %w", err)
+	}
+	return v1.NewConfigDescriptor(digest.FromBytes(buf), int64(len(buf))), buf, nil
+}
+
+type countWriter struct {
+	n int64
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// DetectCompression returns the compression of a stream starting with header,
+// v1.CompressionGzip or v1.CompressionZstd, or an empty string if the stream is not compressed.
+// At least the first four bytes of the stream should be given.
+func DetectCompression(header []byte) string {
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		return v1.CompressionGzip
+	case bytes.HasPrefix(header, zstdMagic):
+		return v1.CompressionZstd
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package content_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/modelpack/model-spec/content"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+)
+
+func gzipBytes(t *testing.T, p []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(p); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zstdBytes(t *testing.T, p []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := zw.Write(p); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestComputeLayer(t *testing.T) {
+	uncompressed := bytes.Repeat([]byte("model weights "), 1024)
+	diffID := digest.FromBytes(uncompressed)
+
+	for _, tt := range []struct {
+		mediaType string
+		blob      []byte
+	}{
+		{mediaType: v1.MediaTypeModelWeightRaw, blob: uncompressed},
+		{mediaType: v1.MediaTypeModelWeight, blob: uncompressed},
+		{mediaType: v1.MediaTypeModelWeightGzip, blob: gzipBytes(t, uncompressed)},
+		{mediaType: v1.MediaTypeModelWeightZstd, blob: zstdBytes(t, uncompressed)},
+	} {
+		layer, err := content.ComputeLayer(bytes.NewReader(tt.blob), tt.mediaType)
+		if err != nil {
+			t.Errorf("%s: %v", tt.mediaType, err)
+			continue
+		}
+		if layer.DiffID != diffID {
+			t.Errorf("%s: expected DiffID %s, got %s", tt.mediaType, diffID, layer.DiffID)
+		}
+		if layer.Descriptor.Digest != digest.FromBytes(tt.blob) || layer.Descriptor.Size != int64(len(tt.blob)) {
+			t.Errorf("%s: descriptor %s/%d does not match the blob", tt.mediaType, layer.Descriptor.Digest, layer.Descriptor.Size)
+		}
+		if layer.Descriptor.MediaType != tt.mediaType {
+			t.Errorf("%s: got media type %s", tt.mediaType, layer.Descriptor.MediaType)
+		}
+	}
+
+	if _, err := content.ComputeLayer(bytes.NewReader(uncompressed), v1.MediaTypeModelWeightGzip); err == nil {
+		t.Error("expected uncompressed blob declared as gzip to fail")
+	}
+}
+
+func TestConfigDescriptor(t *testing.T) {
+	model := v1.Model{
+		Descriptor: v1.ModelDescriptor{Name: "xyz-3-8B-Instruct"},
+		Config:     v1.ModelConfig{ParamSize: "8b"},
+		ModelFS: content.ModelFS([]content.Layer{
+			{DiffID: digest.FromString("a")},
+			{DiffID: digest.FromString("b")},
+		}),
+	}
+
+	desc, buf, err := content.ConfigDescriptor(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if desc.MediaType != v1.MediaTypeModelConfig || desc.Digest != digest.FromBytes(buf) || desc.Size != int64(len(buf)) {
+		t.Errorf("descriptor %+v does not match the marshalled config", desc)
+	}
+
+	var decoded v1.Model
+	if err := json.Unmarshal(buf, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	again, _, err := content.ConfigDescriptor(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Digest != desc.Digest {
+		t.Errorf("expected round-trip to keep digest %s, got %s", desc.Digest, again.Digest)
+	}
+}
@@ -0,0 +1,234 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package estimate estimates the memory footprint of a model from its config and manifest.
+//
+// Weight memory is the total size of the weight layers of the manifest when they are uncompressed,
+// or otherwise the parameter count multiplied by the bytes per parameter of the quantization,
+// or of the precision when the model is not quantized:
+//
+//	| Precision / quantization                                   | Bytes per parameter |
+//	|------------------------------------------------------------|---------------------|
+//	| float64, int64, uint64, complex64                          | 8                   |
+//	| float32, int32, uint32, complex32                          | 4                   |
+//	| float16, bfloat16, int16, uint16                           | 2                   |
+//	| float8_e4m3, float8_e5m2, int8, uint8, bool, fp8           | 1                   |
+//	| complex128                                                 | 16                  |
+//	| q8_k                                                       | 1.140625            |
+//	| q8_1                                                       | 1.125               |
+//	| q8_0                                                       | 1.0625              |
+//	| q6_k                                                       | 0.8203125           |
+//	| q5_1                                                       | 0.75                |
+//	| q5_0, q5_k, q5_k_m, q5_k_s                                 | 0.6875              |
+//	| q4_1                                                       | 0.625               |
+//	| q4_0, q4_k, q4_k_m, q4_k_s, iq4_nl, gptq, awq              | 0.5625              |
+//	| iq4_xs, mxfp4, mxfp4_moe                                   | 0.53125             |
+//	| nf4, fp4, int4                                             | 0.5                 |
+//	| q3_k, q3_k_l, q3_k_m, q3_k_s, iq3_s, iq3_m, iq3_xs         | 0.4296875           |
+//	| iq3_xxs                                                    | 0.3828125           |
+//	| q2_k, q2_k_s                                               | 0.328125            |
+//	| iq2_s, iq2_m                                               | 0.3203125           |
+//	| iq2_xs                                                     | 0.2890625           |
+//	| iq2_xxs, tq2_0                                             | 0.2578125           |
+//	| iq1_m                                                      | 0.21875             |
+//	| tq1_0                                                      | 0.2109375           |
+//	| iq1_s                                                      | 0.1953125           |
+//
+// GGUF quantizations include the block scales in their size, and their mixes, such as q4_k_m, are
+// sized as their main tensor type. gptq and awq assume 4-bit weights with a group size of 128.
+// An unknown quantization is sized from the bits of the quantization details, or else from the
+// precision. When several precisions are listed, the largest one is used.
+//
+// The runtime memory range adds MinOverhead and MaxOverhead to the weight memory, covering
+// activations, KV cache for common context lengths and runtime buffers.
+package estimate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+const (
+	// MinOverhead is the fraction of the weight memory added for the lower runtime memory estimate.
+	MinOverhead = 0.1
+
+	// MaxOverhead is the fraction of the weight memory added for the upper runtime memory estimate.
+	MaxOverhead = 0.5
+)
+
+// Source tells where the weight memory of an estimate comes from.
+type Source string
+
+const (
+	// SourceLayers is the total size of the uncompressed weight layers.
+	SourceLayers Source = "layers"
+
+	// SourceParams is the parameter count multiplied by the bytes per parameter.
+	SourceParams Source = "params"
+)
+
+// quantizationBytes maps quantizations to their size in bytes per parameter.
+var quantizationBytes = map[string]float64{
+	"int8":      1,
+	"fp8":       1,
+	"q8_k":      9.125 / 8,
+	"q8_1":      9.0 / 8,
+	"q8_0":      8.5 / 8,
+	"q6_k":      6.5625 / 8,
+	"q5_1":      6.0 / 8,
+	"q5_0":      5.5 / 8,
+	"q5_k":      5.5 / 8,
+	"q5_k_m":    5.5 / 8,
+	"q5_k_s":    5.5 / 8,
+	"q4_1":      5.0 / 8,
+	"q4_0":      4.5 / 8,
+	"q4_k":      4.5 / 8,
+	"q4_k_m":    4.5 / 8,
+	"q4_k_s":    4.5 / 8,
+	"iq4_nl":    4.5 / 8,
+	"gptq":      4.5 / 8,
+	"awq":       4.5 / 8,
+	"iq4_xs":    4.25 / 8,
+	"mxfp4":     4.25 / 8,
+	"mxfp4_moe": 4.25 / 8,
+	"nf4":       0.5,
+	"fp4":       0.5,
+	"int4":      0.5,
+	"q3_k":      3.4375 / 8,
+	"q3_k_l":    3.4375 / 8,
+	"q3_k_m":    3.4375 / 8,
+	"q3_k_s":    3.4375 / 8,
+	"iq3_s":     3.4375 / 8,
+	"iq3_m":     3.4375 / 8,
+	"iq3_xs":    3.4375 / 8,
+	"iq3_xxs":   3.0625 / 8,
+	"q2_k":      2.625 / 8,
+	"q2_k_s":    2.625 / 8,
+	"iq2_s":     2.5625 / 8,
+	"iq2_m":     2.5625 / 8,
+	"iq2_xs":    2.3125 / 8,
+	"iq2_xxs":   2.0625 / 8,
+	"tq2_0":     2.0625 / 8,
+	"iq1_m":     1.75 / 8,
+	"tq1_0":     1.6875 / 8,
+	"iq1_s":     1.5625 / 8,
+}
+
+// Estimate is the estimated memory footprint of a model.
+type Estimate struct {
+	// WeightBytes is the memory used by the model weights.
+	WeightBytes uint64
+
+	// Source tells how WeightBytes was computed.
+	Source Source
+
+	// MinBytes and MaxBytes bound the memory used to run the model.
+	MinBytes, MaxBytes uint64
+}
+
+// Fits reports whether the model is expected to run within the given memory, using the upper estimate.
+func (e Estimate) Fits(memory uint64) bool {
+	return e.MaxBytes <= memory
+}
+
+// BytesPerParam returns the size of a parameter for the quantization, or for the precision
+// if the quantization is empty or unknown. Names are case-insensitive.
+func BytesPerParam(precision, quantization string) (float64, error) {
+	if quantization != "" {
+		if b, ok := quantizationBytes[strings.ToLower(quantization)]; ok {
+			return b, nil
+		}
+		if precision == "" {
+			return 0, fmt.Errorf("unknown quantization %q", quantization)
+		}
+	}
+	if precision == "" {
+		return 0, errors.New("neither precision nor quantization is set")
+	}
+
+	var largest float64
+	for _, p := range strings.Split(precision, ",") {
+		bits, ok := v1.PrecisionBits(p)
+		if !ok {
+			return 0, fmt.Errorf("unknown precision %q", p)
+		}
+		largest = max(largest, float64(bits)/8)
+	}
+	return largest, nil
+}
+
+// Model estimates the memory footprint of a model from its config, using the weight layer sizes
+// of the manifest when it is not nil and its weight layers are uncompressed.
+func Model(config v1.ModelConfig, manifest *v1.ModelManifest) (Estimate, error) {
+	if manifest != nil {
+		if size, ok := weightLayersSize(*manifest); ok {
+			return newEstimate(size, SourceLayers), nil
+		}
+	}
+
+	if config.ParamSize == "" {
+		return Estimate{}, errors.New("cannot estimate memory: paramSize is not set and no uncompressed weight layers")
+	}
+	params, err := v1.ParseParamSize(config.ParamSize)
+	if err != nil {
+		return Estimate{}, fmt.Errorf("cannot estimate memory: %w", err)
+	}
+	bytes, err := bytesPerParam(config)
+	if err != nil {
+		return Estimate{}, fmt.Errorf("cannot estimate memory: %w", err)
+	}
+	return newEstimate(uint64(math.Ceil(float64(params)*bytes)), SourceParams), nil
+}
+
+// bytesPerParam returns the size of a parameter of the config, using the bits of the quantization
+// details for a quantization missing from the table.
+func bytesPerParam(config v1.ModelConfig) (float64, error) {
+	_, known := quantizationBytes[strings.ToLower(config.Quantization)]
+	if q := config.QuantizationDetails; config.Quantization != "" && !known && q != nil && q.Bits > 0 {
+		return float64(q.Bits) / 8, nil
+	}
+	return BytesPerParam(config.Precision, config.Quantization)
+}
+
+// weightLayersSize returns the total size of the weight layers, if there is any and none is compressed.
+func weightLayersSize(manifest v1.ModelManifest) (uint64, bool) {
+	layers := manifest.LayersByComponent(v1.ComponentWeight)
+	if len(layers) == 0 {
+		return 0, false
+	}
+	var size uint64
+	for _, layer := range layers {
+		mt, err := v1.ParseLayerMediaType(layer.MediaType)
+		if err != nil || mt.Compression != "" || layer.Size < 0 {
+			return 0, false
+		}
+		size += uint64(layer.Size)
+	}
+	return size, true
+}
+
+func newEstimate(weights uint64, source Source) Estimate {
+	return Estimate{
+		WeightBytes: weights,
+		Source:      source,
+		MinBytes:    uint64(math.Ceil(float64(weights) * (1 + MinOverhead))),
+		MaxBytes:    uint64(math.Ceil(float64(weights) * (1 + MaxOverhead))),
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package estimate_test
+
+import (
+	"testing"
+
+	"github.com/modelpack/model-spec/estimate"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+)
+
+func manifest(mediaTypes ...string) *v1.ModelManifest {
+	config := v1.NewConfigDescriptor(digest.FromString("config"), 100)
+	m := v1.NewModelManifest(config)
+	for i, mt := range mediaTypes {
+		m.Layers = append(m.Layers, v1.NewLayerDescriptor(mt, digest.FromString(mt), 1000*int64(i+1), "file"))
+	}
+	return &m
+}
+
+func TestModel(t *testing.T) {
+	for i, tt := range []struct {
+		config   v1.ModelConfig
+		manifest *v1.ModelManifest
+		weights  uint64
+		source   estimate.Source
+		fail     bool
+	}{
+		{
+			config:  v1.ModelConfig{ParamSize: "8b", Precision: "bfloat16"},
+			weights: 16e9,
+			source:  estimate.SourceParams,
+		},
+		{
+			config:  v1.ModelConfig{ParamSize: "8b", Precision: "bfloat16", Quantization: "Q4_K_M"},
+			weights: 4.5e9,
+			source:  estimate.SourceParams,
+		},
+		{
+			config:  v1.ModelConfig{ParamSize: "1.5b", Precision: "float16, float8_e4m3"},
+			weights: 3e9,
+			source:  estimate.SourceParams,
+		},
+		{
+			config:   v1.ModelConfig{ParamSize: "8b", Precision: "bfloat16"},
+			manifest: manifest(v1.MediaTypeModelWeightRaw, v1.MediaTypeModelWeightConfigRaw, v1.MediaTypeModelWeight),
+			weights:  4000,
+			source:   estimate.SourceLayers,
+		},
+		{
+			config:   v1.ModelConfig{ParamSize: "8b", Precision: "float32"},
+			manifest: manifest(v1.MediaTypeModelWeightGzip),
+			weights:  32e9,
+			source:   estimate.SourceParams,
+		},
+		{config: v1.ModelConfig{Precision: "bfloat16"}, fail: true},
+		{config: v1.ModelConfig{ParamSize: "8b"}, fail: true},
+		{config: v1.ModelConfig{ParamSize: "8b", Precision: "float12"}, fail: true},
+		{config: v1.ModelConfig{ParamSize: "8b", Quantization: "magic"}, fail: true},
+		{config: v1.ModelConfig{ParamSize: "8b", Quantization: "q4_1"}, weights: 5e9, source: estimate.SourceParams},
+		{config: v1.ModelConfig{ParamSize: "8b", Quantization: "FP8"}, weights: 8e9, source: estimate.SourceParams},
+		{
+			config:  v1.ModelConfig{ParamSize: "8b", Precision: "bfloat16", Quantization: "hqq", QuantizationDetails: &v1.QuantizationDetails{Method: "hqq", Bits: 3}},
+			weights: 3e9,
+			source:  estimate.SourceParams,
+		},
+		{config: v1.ModelConfig{ParamSize: "8b", Precision: "bfloat16", Quantization: "magic"}, weights: 16e9, source: estimate.SourceParams},
+		{config: v1.ModelConfig{ParamSize: "8b", Precision: "bfloat16"}, manifest: manifest(v1.MediaTypeModelDoc), weights: 16e9, source: estimate.SourceParams},
+	} {
+		e, err := estimate.Model(tt.config, tt.manifest)
+		if (err != nil) != tt.fail {
+			t.Errorf("test %d: expected failure %t, got %v", i, tt.fail, err)
+			continue
+		}
+		if tt.fail {
+			continue
+		}
+		if e.WeightBytes != tt.weights || e.Source != tt.source {
+			t.Errorf("test %d: expected %d bytes from %s, got %d bytes from %s", i, tt.weights, tt.source, e.WeightBytes, e.Source)
+		}
+		if e.MinBytes <= e.WeightBytes || e.MaxBytes <= e.MinBytes {
+			t.Errorf("test %d: invalid runtime memory range [%d, %d] for %d weight bytes", i, e.MinBytes, e.MaxBytes, e.WeightBytes)
+		}
+		if !e.Fits(e.MaxBytes) || e.Fits(e.MaxBytes-1) {
+			t.Errorf("test %d: expected to fit exactly %d bytes", i, e.MaxBytes)
+		}
+	}
+}
+
+func TestBytesPerParam(t *testing.T) {
+	// the quantizations of the GGUF file and tensor types
+	for _, quantization := range []string{
+		"q4_0", "q4_1", "q5_0", "q5_1", "q8_0", "q8_1", "q8_k", "q2_k", "q2_k_s", "q3_k", "q3_k_s", "q3_k_m", "q3_k_l",
+		"q4_k", "q4_k_s", "q4_k_m", "q5_k", "q5_k_s", "q5_k_m", "q6_k", "iq1_s", "iq1_m", "iq2_xxs", "iq2_xs", "iq2_s",
+		"iq2_m", "iq3_xxs", "iq3_xs", "iq3_s", "iq3_m", "iq4_nl", "iq4_xs", "tq1_0", "tq2_0", "mxfp4", "mxfp4_moe",
+	} {
+		if b, err := estimate.BytesPerParam("", quantization); err != nil || b <= 0 || b > 2 {
+			t.Errorf("%s: unexpected size %v, %v", quantization, b, err)
+		}
+	}
+}
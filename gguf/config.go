@@ -0,0 +1,252 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gguf
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/modelpack/model-spec/content"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+// Format is the ModelConfig.Format of GGUF files.
+const Format = "gguf"
+
+// ReadLayer reads the GGUF header of a weight layer declared with the given media type. The layer
+// is decompressed as needed and, for tar layers, the first regular file with the .gguf extension is read.
+func ReadLayer(r io.Reader, mediaType string) (*File, error) {
+	mt, err := v1.ParseLayerMediaType(mediaType)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := content.Decompress(r, mt.Compression)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	if !mt.Archived {
+		return Read(zr)
+	}
+
+	tr := tar.NewReader(zr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("no .gguf file in tar layer")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tar content: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && strings.HasSuffix(strings.ToLower(hdr.Name), ".gguf") {
+			f, err := Read(tr)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", hdr.Name, err)
+			}
+			return f, nil
+		}
+	}
+}
+
+// Params returns the number of parameters of the tensors.
+func (f *File) Params() (uint64, error) {
+	var total uint64
+	for _, t := range f.Tensors {
+		n, err := t.Params()
+		if err != nil {
+			return 0, err
+		}
+		if total+n < total {
+			return 0, errors.New("parameter count overflows")
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// Type returns the name of the type of most of the tensors, such as "Q4_K_M" or "BF16". It is
+// general.file_type when set, or otherwise the tensor type holding the most parameters.
+// It is empty when the type is unknown.
+func (f *File) Type() string {
+	if ft, ok := f.UintValue("general.file_type"); ok {
+		return fileTypes[FileType(ft)]
+	}
+	counts := make(map[TensorType]uint64)
+	var dominant TensorType
+	for _, t := range f.Tensors {
+		n, _ := t.Params()
+		counts[t.Type] += n
+		if counts[t.Type] > counts[dominant] || counts[t.Type] == counts[dominant] && t.Type < dominant {
+			dominant = t.Type
+		}
+	}
+	if len(counts) == 0 {
+		return ""
+	}
+	return tensorTypes[dominant]
+}
+
+// Model maps the metadata to a model. The general.name is the model name and general.architecture,
+// such as "llama", is the model family. The config has the gguf format, the parameter size counted
+// from the tensor infos, the quantization or the precision of the file type, and the architecture
+// details and context length read from the keys prefixed with the general.architecture.
+// When the mapped config is inconsistent, the model is returned along with the error.
+func (f *File) Model() (v1.Model, error) {
+	m, err := f.model()
+	if err != nil {
+		return m, err
+	}
+	if err := m.Config.Validate(); err != nil {
+		return m, fmt.Errorf("inconsistent GGUF metadata: %w", err)
+	}
+	return m, nil
+}
+
+func (f *File) model() (v1.Model, error) {
+	var m v1.Model
+	m.Descriptor.Name, _ = f.StringValue("general.name")
+	arch, _ := f.StringValue("general.architecture")
+	m.Descriptor.Family = arch
+
+	c := &m.Config
+	c.Format = Format
+	params, err := f.Params()
+	if err != nil {
+		return m, err
+	}
+	if params > 0 {
+		c.ParamSize = v1.FormatParamSize(params)
+	}
+	if typ := f.Type(); typ != "" {
+		if precision, ok := precisions[typ]; ok {
+			c.Precision = precision
+		} else {
+			c.Quantization = strings.ToLower(typ)
+		}
+	}
+
+	if arch != "" {
+		details := &v1.ArchitectureDetails{}
+		for _, field := range []struct {
+			value *int
+			key   string
+		}{
+			{&details.NumLayers, "block_count"},
+			{&details.HiddenSize, "embedding_length"},
+			{&details.NumAttentionHeads, "attention.head_count"},
+			{&details.NumKeyValueHeads, "attention.head_count_kv"},
+			{&details.HeadDim, "attention.key_length"},
+			{&details.VocabSize, "vocab_size"},
+			{&details.MaxPositionEmbeddings, "context_length"},
+			{&details.NumExperts, "expert_count"},
+			{&details.NumActiveExperts, "expert_used_count"},
+		} {
+			if v, ok := f.UintValue(arch + "." + field.key); ok && v <= 1<<31-1 {
+				*field.value = int(v)
+			}
+		}
+		if tokens, ok := f.ArrayValue("tokenizer.ggml.tokens"); ok && details.VocabSize == 0 {
+			details.VocabSize = len(tokens)
+		}
+		if *details != (v1.ArchitectureDetails{}) {
+			c.ArchitectureDetails = details
+		}
+		if details.NumAttentionHeads > 0 {
+			c.Architecture = "transformer"
+		}
+		if details.MaxPositionEmbeddings > 0 {
+			c.Runtime = &v1.ModelRuntime{MaxContextLength: details.MaxPositionEmbeddings}
+		}
+	}
+	return m, nil
+}
+
+// Check compares the config with the one mapped from the metadata, reporting the disagreements
+// on the format, parameter size, precision, quantization, architecture, architecture details and context
+// length, along with the inconsistencies of the metadata. The fields missing from either config are not
+// compared, except that a precision declared without quantization is reported for a quantized file.
+func (f *File) Check(c v1.ModelConfig) error {
+	m, err := f.model()
+	if err != nil {
+		return err
+	}
+	gguf := m.Config
+
+	var errs []error
+	if err := gguf.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("inconsistent GGUF metadata: %w", err))
+	}
+	if c.Format != "" && !strings.EqualFold(c.Format, Format) {
+		errs = append(errs, fmt.Errorf("format is %q, expected %s", c.Format, Format))
+	}
+	if c.ParamSize != "" && gguf.ParamSize != "" {
+		params, _ := f.Params()
+		if ok, err := v1.ParamSizeMatches(c.ParamSize, params); err != nil {
+			errs = append(errs, err)
+		} else if !ok {
+			errs = append(errs, fmt.Errorf("paramSize %s does not match the %d parameters of the file (%s)", c.ParamSize, params, gguf.ParamSize))
+		}
+	}
+	if c.Precision != "" && gguf.Precision != "" && !slices.ContainsFunc(strings.Split(c.Precision, ","), func(p string) bool {
+		return strings.EqualFold(strings.TrimSpace(p), gguf.Precision)
+	}) {
+		errs = append(errs, fmt.Errorf("precision is %q, expected %s", c.Precision, gguf.Precision))
+	}
+	if c.Precision != "" && c.Quantization == "" && gguf.Quantization != "" {
+		errs = append(errs, fmt.Errorf("precision is %q without quantization, but the file is quantized as %s", c.Precision, gguf.Quantization))
+	}
+	if c.Quantization != "" && !strings.EqualFold(c.Quantization, gguf.Quantization) {
+		if gguf.Quantization == "" {
+			errs = append(errs, fmt.Errorf("quantization is %q, but the file is not quantized", c.Quantization))
+		} else {
+			errs = append(errs, fmt.Errorf("quantization is %q, expected %s", c.Quantization, gguf.Quantization))
+		}
+	}
+	if c.Architecture != "" && gguf.Architecture != "" && !strings.EqualFold(c.Architecture, gguf.Architecture) {
+		errs = append(errs, fmt.Errorf("architecture is %q, expected %s", c.Architecture, gguf.Architecture))
+	}
+
+	if c.ArchitectureDetails != nil && gguf.ArchitectureDetails != nil {
+		declared, read := *c.ArchitectureDetails, *gguf.ArchitectureDetails
+		for _, field := range []struct {
+			name           string
+			declared, read int
+		}{
+			{"numLayers", declared.NumLayers, read.NumLayers},
+			{"hiddenSize", declared.HiddenSize, read.HiddenSize},
+			{"numAttentionHeads", declared.NumAttentionHeads, read.NumAttentionHeads},
+			{"numKeyValueHeads", declared.NumKeyValueHeads, read.NumKeyValueHeads},
+			{"headDim", declared.HeadDim, read.HeadDim},
+			{"vocabSize", declared.VocabSize, read.VocabSize},
+			{"maxPositionEmbeddings", declared.MaxPositionEmbeddings, read.MaxPositionEmbeddings},
+			{"numExperts", declared.NumExperts, read.NumExperts},
+			{"numActiveExperts", declared.NumActiveExperts, read.NumActiveExperts},
+		} {
+			if field.declared != 0 && field.read != 0 && field.declared != field.read {
+				errs = append(errs, fmt.Errorf("architectureDetails.%s is %d, expected %d", field.name, field.declared, field.read))
+			}
+		}
+	}
+	if c.Runtime != nil && gguf.Runtime != nil && c.Runtime.MaxContextLength > gguf.Runtime.MaxContextLength {
+		errs = append(errs, fmt.Errorf("runtime.maxContextLength %d exceeds the context length %d of the file", c.Runtime.MaxContextLength, gguf.Runtime.MaxContextLength))
+	}
+	return errors.Join(errs...)
+}
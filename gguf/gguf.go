@@ -0,0 +1,283 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gguf reads the metadata and tensor infos of GGUF files, without reading the tensor data,
+// and maps them to the model config.
+package gguf
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+)
+
+// Magic is the first four bytes of a GGUF file.
+const Magic = "GGUF"
+
+// Limits on the header of a GGUF file, to reject corrupted files before allocating memory.
+const (
+	MaxStringLength = 64 << 20
+	MaxArrayLength  = 1 << 22
+	MaxTensors      = 1 << 20
+	MaxDimensions   = 4
+)
+
+// ValueType is the type of a metadata value.
+type ValueType uint32
+
+// Metadata value types. Values are decoded as the Go type in parentheses.
+const (
+	TypeUint8   ValueType = 0  // (uint8)
+	TypeInt8    ValueType = 1  // (int8)
+	TypeUint16  ValueType = 2  // (uint16)
+	TypeInt16   ValueType = 3  // (int16)
+	TypeUint32  ValueType = 4  // (uint32)
+	TypeInt32   ValueType = 5  // (int32)
+	TypeFloat32 ValueType = 6  // (float32)
+	TypeBool    ValueType = 7  // (bool)
+	TypeString  ValueType = 8  // (string)
+	TypeArray   ValueType = 9  // ([]any)
+	TypeUint64  ValueType = 10 // (uint64)
+	TypeInt64   ValueType = 11 // (int64)
+	TypeFloat64 ValueType = 12 // (float64)
+)
+
+// TensorInfo describes a tensor of a GGUF file.
+type TensorInfo struct {
+	Name string
+
+	// Dimensions are the number of elements of each dimension, innermost first.
+	Dimensions []uint64
+
+	Type TensorType
+
+	// Offset is the offset of the tensor data, relative to the start of the data section.
+	Offset uint64
+}
+
+// Params returns the number of elements of the tensor. Quantized tensors are counted in elements,
+// not in blocks, so this is the number of parameters they hold.
+func (t TensorInfo) Params() (uint64, error) {
+	n := uint64(1)
+	for _, d := range t.Dimensions {
+		hi, lo := bits.Mul64(n, d)
+		if hi != 0 {
+			return 0, fmt.Errorf("tensor %q: dimensions %v overflow", t.Name, t.Dimensions)
+		}
+		n = lo
+	}
+	return n, nil
+}
+
+// File is the header of a GGUF file.
+type File struct {
+	Version uint32
+
+	// Metadata maps the keys, such as "general.architecture", to their decoded value.
+	Metadata map[string]any
+
+	// Keys lists the metadata keys in file order.
+	Keys []string
+
+	Tensors []TensorInfo
+}
+
+// Read reads the header of a GGUF file, from its magic to its last tensor info.
+// Only little-endian files of version 2 and 3 are supported.
+func Read(r io.Reader) (*File, error) {
+	d := &decoder{r: bufio.NewReader(r)}
+	magic := make([]byte, len(Magic))
+	if _, err := io.ReadFull(d.r, magic); err != nil {
+		return nil, fmt.Errorf("failed to read magic: %w", err)
+	}
+	if string(magic) != Magic {
+		return nil, fmt.Errorf("invalid magic %q, expected %q", magic, Magic)
+	}
+
+	f := &File{Metadata: make(map[string]any)}
+	f.Version = d.uint32()
+	if d.err == nil && f.Version != 2 && f.Version != 3 {
+		return nil, fmt.Errorf("unsupported version %d", f.Version)
+	}
+	tensors := d.uint64()
+	kvs := d.uint64()
+	if d.err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", d.err)
+	}
+	if tensors > MaxTensors {
+		return nil, fmt.Errorf("%d tensors exceed the maximum of %d", tensors, MaxTensors)
+	}
+
+	for i := uint64(0); i < kvs; i++ {
+		key := d.string()
+		value := d.value(ValueType(d.uint32()))
+		if d.err != nil {
+			return nil, fmt.Errorf("failed to read metadata %d: %w", i, d.err)
+		}
+		if _, ok := f.Metadata[key]; ok {
+			return nil, fmt.Errorf("duplicate metadata key %q", key)
+		}
+		f.Metadata[key] = value
+		f.Keys = append(f.Keys, key)
+	}
+
+	for i := uint64(0); i < tensors; i++ {
+		t := TensorInfo{Name: d.string()}
+		n := d.uint32()
+		if d.err == nil && n > MaxDimensions {
+			return nil, fmt.Errorf("tensor %q has %d dimensions, expected at most %d", t.Name, n, MaxDimensions)
+		}
+		for j := uint32(0); j < n && d.err == nil; j++ {
+			t.Dimensions = append(t.Dimensions, d.uint64())
+		}
+		t.Type = TensorType(d.uint32())
+		t.Offset = d.uint64()
+		if d.err != nil {
+			return nil, fmt.Errorf("failed to read tensor info %d: %w", i, d.err)
+		}
+		f.Tensors = append(f.Tensors, t)
+	}
+	return f, nil
+}
+
+// decoder reads little-endian values, keeping the first error.
+type decoder struct {
+	r   *bufio.Reader
+	buf [8]byte
+	err error
+}
+
+func (d *decoder) read(n int) []byte {
+	if d.err != nil {
+		return d.buf[:n]
+	}
+	if _, err := io.ReadFull(d.r, d.buf[:n]); err != nil {
+		d.err = err
+	}
+	return d.buf[:n]
+}
+
+func (d *decoder) uint32() uint32 {
+	return binary.LittleEndian.Uint32(d.read(4))
+}
+
+func (d *decoder) uint64() uint64 {
+	return binary.LittleEndian.Uint64(d.read(8))
+}
+
+func (d *decoder) string() string {
+	n := d.uint64()
+	if d.err != nil {
+		return ""
+	}
+	if n > MaxStringLength {
+		d.err = fmt.Errorf("string of %d bytes exceeds the maximum of %d", n, MaxStringLength)
+		return ""
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		d.err = err
+	}
+	return string(b)
+}
+
+func (d *decoder) value(t ValueType) any {
+	if d.err != nil {
+		return nil
+	}
+	switch t {
+	case TypeUint8:
+		return d.read(1)[0]
+	case TypeInt8:
+		return int8(d.read(1)[0])
+	case TypeUint16:
+		return binary.LittleEndian.Uint16(d.read(2))
+	case TypeInt16:
+		return int16(binary.LittleEndian.Uint16(d.read(2)))
+	case TypeUint32:
+		return d.uint32()
+	case TypeInt32:
+		return int32(d.uint32())
+	case TypeFloat32:
+		return math.Float32frombits(d.uint32())
+	case TypeBool:
+		return d.read(1)[0] != 0
+	case TypeString:
+		return d.string()
+	case TypeUint64:
+		return d.uint64()
+	case TypeInt64:
+		return int64(d.uint64())
+	case TypeFloat64:
+		return math.Float64frombits(d.uint64())
+	case TypeArray:
+		elem := ValueType(d.uint32())
+		n := d.uint64()
+		if elem == TypeArray {
+			d.err = errors.New("nested arrays are not supported")
+		}
+		if d.err == nil && n > MaxArrayLength {
+			d.err = fmt.Errorf("array of %d values exceeds the maximum of %d", n, MaxArrayLength)
+		}
+		var values []any
+		for i := uint64(0); i < n && d.err == nil; i++ {
+			values = append(values, d.value(elem))
+		}
+		return values
+	default:
+		d.err = fmt.Errorf("unknown value type %d", t)
+		return nil
+	}
+}
+
+// UintValue returns the metadata value of key as an unsigned integer, if it is a non-negative integer.
+func (f *File) UintValue(key string) (uint64, bool) {
+	switch v := f.Metadata[key].(type) {
+	case uint8:
+		return uint64(v), true
+	case uint16:
+		return uint64(v), true
+	case uint32:
+		return uint64(v), true
+	case uint64:
+		return v, true
+	case int8:
+		return uint64(v), v >= 0
+	case int16:
+		return uint64(v), v >= 0
+	case int32:
+		return uint64(v), v >= 0
+	case int64:
+		return uint64(v), v >= 0
+	}
+	return 0, false
+}
+
+// StringValue returns the metadata value of key, if it is a string.
+func (f *File) StringValue(key string) (string, bool) {
+	v, ok := f.Metadata[key].(string)
+	return v, ok
+}
+
+// ArrayValue returns the metadata value of key, if it is an array.
+func (f *File) ArrayValue(key string) ([]any, bool) {
+	v, ok := f.Metadata[key].([]any)
+	return v, ok
+}
@@ -0,0 +1,287 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gguf_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/modelpack/model-spec/gguf"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+// writer builds the header of a GGUF file.
+type writer struct {
+	bytes.Buffer
+}
+
+func (w *writer) put(v any) {
+	binary.Write(&w.Buffer, binary.LittleEndian, v)
+}
+
+func (w *writer) string(s string) {
+	w.put(uint64(len(s)))
+	w.WriteString(s)
+}
+
+func (w *writer) kv(key string, t gguf.ValueType, v any) {
+	w.string(key)
+	w.put(uint32(t))
+	switch v := v.(type) {
+	case string:
+		w.string(v)
+	case []string:
+		w.put(uint32(gguf.TypeString))
+		w.put(uint64(len(v)))
+		for _, s := range v {
+			w.string(s)
+		}
+	default:
+		w.put(v)
+	}
+}
+
+func (w *writer) tensor(name string, typ gguf.TensorType, dims ...uint64) {
+	w.string(name)
+	w.put(uint32(len(dims)))
+	for _, d := range dims {
+		w.put(d)
+	}
+	w.put(uint32(typ))
+	w.put(uint64(0))
+}
+
+// tinyLlama returns the header of a llama model quantized in q4_k_m with 4352 parameters.
+func tinyLlama() []byte {
+	var w writer
+	w.WriteString(gguf.Magic)
+	w.put(uint32(3))
+	w.put(uint64(3))
+	w.put(uint64(9))
+	w.kv("general.architecture", gguf.TypeString, "llama")
+	w.kv("general.name", gguf.TypeString, "Tiny Llama")
+	w.kv("general.file_type", gguf.TypeUint32, uint32(15))
+	w.kv("llama.block_count", gguf.TypeUint32, uint32(2))
+	w.kv("llama.embedding_length", gguf.TypeUint32, uint32(64))
+	w.kv("llama.attention.head_count", gguf.TypeUint32, uint32(4))
+	w.kv("llama.attention.head_count_kv", gguf.TypeUint32, uint32(2))
+	w.kv("llama.context_length", gguf.TypeUint64, uint64(2048))
+	w.kv("tokenizer.ggml.tokens", gguf.TypeArray, []string{"<s>", "</s>", "a"})
+	w.tensor("token_embd.weight", 12, 64, 3)
+	w.tensor("blk.0.attn_q.weight", 12, 64, 64)
+	w.tensor("output_norm.weight", 0, 64)
+	return w.Bytes()
+}
+
+func TestRead(t *testing.T) {
+	f, err := gguf.Read(bytes.NewReader(tinyLlama()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Version != 3 || len(f.Keys) != 9 || f.Keys[0] != "general.architecture" || len(f.Tensors) != 3 {
+		t.Errorf("unexpected header %+v", f)
+	}
+	if n, ok := f.UintValue("llama.context_length"); !ok || n != 2048 {
+		t.Errorf("expected context length 2048, got %d", n)
+	}
+	if tokens, ok := f.ArrayValue("tokenizer.ggml.tokens"); !ok || len(tokens) != 3 || tokens[2] != "a" {
+		t.Errorf("unexpected tokens %v", tokens)
+	}
+	if tt := f.Tensors[1]; tt.Name != "blk.0.attn_q.weight" || tt.Type.String() != "Q4_K" || len(tt.Dimensions) != 2 {
+		t.Errorf("unexpected tensor %+v", tt)
+	}
+	if f.Type() != "Q4_K_M" {
+		t.Errorf("expected file type Q4_K_M, got %s", f.Type())
+	}
+
+	header := func(version uint32, tensors, kvs uint64, rest ...func(*writer)) []byte {
+		var w writer
+		w.WriteString(gguf.Magic)
+		w.put(version)
+		w.put(tensors)
+		w.put(kvs)
+		for _, f := range rest {
+			f(&w)
+		}
+		return w.Bytes()
+	}
+	for name, blob := range map[string][]byte{
+		"bad magic":       []byte("GGML\x03\x00\x00\x00"),
+		"version 1":       header(1, 0, 0),
+		"truncated":       tinyLlama()[:100],
+		"too many tensor": header(3, 1<<40, 0),
+		"unknown type":    header(3, 0, 1, func(w *writer) { w.string("k"); w.put(uint32(42)) }),
+		"nested arrays":   header(3, 0, 1, func(w *writer) { w.string("k"); w.put(uint32(gguf.TypeArray)); w.put(uint32(gguf.TypeArray)) }),
+		"huge string":     header(3, 0, 1, func(w *writer) { w.put(uint64(1 << 62)) }),
+		"huge array": header(3, 0, 1, func(w *writer) {
+			w.string("k")
+			w.put(uint32(gguf.TypeArray))
+			w.put(uint32(gguf.TypeUint8))
+			w.put(uint64(gguf.MaxArrayLength + 1))
+			w.Write(make([]byte, gguf.MaxArrayLength+1))
+		}),
+		"duplicate key": header(3, 0, 2, func(w *writer) {
+			w.kv("k", gguf.TypeBool, true)
+			w.kv("k", gguf.TypeBool, true)
+		}),
+		"too many dimensions": header(3, 1, 0, func(w *writer) { w.tensor("t", 0, 1, 1, 1, 1, 1) }),
+	} {
+		if _, err := gguf.Read(bytes.NewReader(blob)); err == nil {
+			t.Errorf("%s: expected failure", name)
+		}
+	}
+}
+
+func TestReadLayer(t *testing.T) {
+	var tarball bytes.Buffer
+	zw := gzip.NewWriter(&tarball)
+	tw := tar.NewWriter(zw)
+	for name, content := range map[string][]byte{"README.md": []byte("# Tiny"), "tiny-q4_k_m.gguf": tinyLlama()} {
+		if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name      string
+		mediaType string
+		blob      []byte
+		fail      bool
+	}{
+		{name: "raw", mediaType: v1.MediaTypeModelWeightRaw, blob: tinyLlama()},
+		{name: "tar gzip", mediaType: v1.MediaTypeModelWeightGzip, blob: tarball.Bytes()},
+		{name: "tar without gguf", mediaType: v1.MediaTypeModelWeightGzip, blob: gzipOf(t, make([]byte, 1024)), fail: true},
+		{name: "not a weight", mediaType: "application/json", blob: tinyLlama(), fail: true},
+	} {
+		f, err := gguf.ReadLayer(bytes.NewReader(tt.blob), tt.mediaType)
+		if (err != nil) != tt.fail {
+			t.Errorf("%s: expected failure %t, got %v", tt.name, tt.fail, err)
+			continue
+		}
+		if err == nil && len(f.Tensors) != 3 {
+			t.Errorf("%s: expected 3 tensors, got %d", tt.name, len(f.Tensors))
+		}
+	}
+}
+
+func gzipOf(t *testing.T, p []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(p); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestModel(t *testing.T) {
+	f, err := gguf.Read(bytes.NewReader(tinyLlama()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := f.Model()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := m.Config
+	if m.Descriptor.Name != "Tiny Llama" || m.Descriptor.Family != "llama" {
+		t.Errorf("unexpected descriptor %+v", m.Descriptor)
+	}
+	if c.Format != "gguf" || c.ParamSize != "4.4k" || c.Quantization != "q4_k_m" || c.Precision != "" || c.Architecture != "transformer" {
+		t.Errorf("unexpected config %+v", c)
+	}
+	expected := v1.ArchitectureDetails{NumLayers: 2, HiddenSize: 64, NumAttentionHeads: 4, NumKeyValueHeads: 2, VocabSize: 3, MaxPositionEmbeddings: 2048}
+	if c.ArchitectureDetails == nil || *c.ArchitectureDetails != expected {
+		t.Errorf("expected architecture details %+v, got %+v", expected, c.ArchitectureDetails)
+	}
+	if c.Runtime == nil || c.Runtime.MaxContextLength != 2048 {
+		t.Errorf("expected a context length of 2048, got %+v", c.Runtime)
+	}
+
+	for i, tt := range []struct {
+		config   v1.ModelConfig
+		mismatch []string
+	}{
+		{config: v1.ModelConfig{Format: "GGUF", ParamSize: "4.4k", Quantization: "Q4_K_M", Architecture: "transformer"}},
+		{config: v1.ModelConfig{ArchitectureDetails: &v1.ArchitectureDetails{NumLayers: 2}, Runtime: &v1.ModelRuntime{MaxContextLength: 1024}}},
+		{config: v1.ModelConfig{Format: "safetensors", ParamSize: "5k"}, mismatch: []string{"format", "paramSize"}},
+		{config: v1.ModelConfig{Quantization: "q8_0", Architecture: "cnn"}, mismatch: []string{"quantization", "architecture"}},
+		{config: v1.ModelConfig{Precision: "float16", Quantization: "q4_k_m"}},
+		{config: v1.ModelConfig{Precision: "float16"}, mismatch: []string{"precision"}},
+		{
+			config:   v1.ModelConfig{ArchitectureDetails: &v1.ArchitectureDetails{NumLayers: 32}, Runtime: &v1.ModelRuntime{MaxContextLength: 8192}},
+			mismatch: []string{"numLayers", "maxContextLength"},
+		},
+	} {
+		err := f.Check(tt.config)
+		if (err != nil) != (len(tt.mismatch) > 0) {
+			t.Errorf("test %d: expected mismatches %v, got %v", i, tt.mismatch, err)
+			continue
+		}
+		for _, field := range tt.mismatch {
+			if !strings.Contains(err.Error(), field) {
+				t.Errorf("test %d: expected a mismatch on %s, got %v", i, field, err)
+			}
+		}
+	}
+
+	for i, tt := range []struct {
+		fileType  uint32
+		precision string
+		fail      bool
+	}{
+		{fileType: 0, precision: "float32"},
+		{fileType: 32, precision: "float32,BFloat16"},
+		{fileType: 0, precision: "float16", fail: true},
+		{fileType: 32, precision: "float16", fail: true},
+	} {
+		f.Metadata["general.file_type"] = tt.fileType
+		if err := f.Check(v1.ModelConfig{Precision: tt.precision}); (err != nil) != tt.fail {
+			t.Errorf("test %d: expected failure %t for precision %s, got %v", i, tt.fail, tt.precision, err)
+		}
+	}
+
+	// an unknown file type is not a quantization, inconsistent metadata is returned with the model
+	f.Metadata["general.file_type"] = uint32(99)
+	f.Metadata["llama.attention.head_count"] = uint32(3)
+	if f.Type() != "" {
+		t.Errorf("expected no type for an unknown file type, got %q", f.Type())
+	}
+	m, err = f.Model()
+	if err == nil || m.Config.Quantization != "" || m.Config.ArchitectureDetails == nil || m.Config.ArchitectureDetails.NumAttentionHeads != 3 {
+		t.Errorf("expected the inconsistent model to be returned with an error, got %+v, %v", m.Config, err)
+	}
+	if err := f.Check(v1.ModelConfig{Architecture: "cnn"}); err == nil || !strings.Contains(err.Error(), "inconsistent GGUF metadata") || !strings.Contains(err.Error(), "architecture") {
+		t.Errorf("expected the inconsistent metadata and the architecture to be reported, got %v", err)
+	}
+}
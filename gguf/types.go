@@ -0,0 +1,124 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gguf
+
+import "fmt"
+
+// TensorType is the ggml type of a tensor.
+type TensorType uint32
+
+// tensorTypes maps the ggml tensor types to their name.
+var tensorTypes = map[TensorType]string{
+	0:  "F32",
+	1:  "F16",
+	2:  "Q4_0",
+	3:  "Q4_1",
+	6:  "Q5_0",
+	7:  "Q5_1",
+	8:  "Q8_0",
+	9:  "Q8_1",
+	10: "Q2_K",
+	11: "Q3_K",
+	12: "Q4_K",
+	13: "Q5_K",
+	14: "Q6_K",
+	15: "Q8_K",
+	16: "IQ2_XXS",
+	17: "IQ2_XS",
+	18: "IQ3_XXS",
+	19: "IQ1_S",
+	20: "IQ4_NL",
+	21: "IQ3_S",
+	22: "IQ2_S",
+	23: "IQ4_XS",
+	24: "I8",
+	25: "I16",
+	26: "I32",
+	27: "I64",
+	28: "F64",
+	29: "IQ1_M",
+	30: "BF16",
+	34: "TQ1_0",
+	35: "TQ2_0",
+	39: "MXFP4",
+}
+
+func (t TensorType) String() string {
+	if name, ok := tensorTypes[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("type %d", uint32(t))
+}
+
+// FileType is the general.file_type of a GGUF file, the type of most of its tensors.
+type FileType uint32
+
+// fileTypes maps the file types to their name, without their MOSTLY_ prefix.
+var fileTypes = map[FileType]string{
+	0:  "F32",
+	1:  "F16",
+	2:  "Q4_0",
+	3:  "Q4_1",
+	7:  "Q8_0",
+	8:  "Q5_0",
+	9:  "Q5_1",
+	10: "Q2_K",
+	11: "Q3_K_S",
+	12: "Q3_K_M",
+	13: "Q3_K_L",
+	14: "Q4_K_S",
+	15: "Q4_K_M",
+	16: "Q5_K_S",
+	17: "Q5_K_M",
+	18: "Q6_K",
+	19: "IQ2_XXS",
+	20: "IQ2_XS",
+	21: "Q2_K_S",
+	22: "IQ3_XS",
+	23: "IQ3_XXS",
+	24: "IQ1_S",
+	25: "IQ4_NL",
+	26: "IQ3_S",
+	27: "IQ3_M",
+	28: "IQ2_S",
+	29: "IQ2_M",
+	30: "IQ4_XS",
+	31: "IQ1_M",
+	32: "BF16",
+	36: "TQ1_0",
+	37: "TQ2_0",
+	38: "MXFP4_MOE",
+}
+
+func (t FileType) String() string {
+	if name, ok := fileTypes[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("file type %d", uint32(t))
+}
+
+// precisions maps the unquantized tensor and file type names to the precisions of the model config.
+var precisions = map[string]string{
+	"F32":  "float32",
+	"F16":  "float16",
+	"BF16": "bfloat16",
+	"F64":  "float64",
+	"I8":   "int8",
+	"I16":  "int16",
+	"I32":  "int32",
+	"I64":  "int64",
+}
@@ -0,0 +1,271 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package huggingface imports model metadata from the files of HuggingFace model repositories:
+// config.json, generation_config.json and the front matter of the README.md model card.
+package huggingface
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+const (
+	// ConfigFile is the name of the HuggingFace model config file.
+	ConfigFile = "config.json"
+
+	// GenerationConfigFile is the name of the HuggingFace generation config file.
+	GenerationConfigFile = "generation_config.json"
+)
+
+// Inference records a field of the model inferred from a HuggingFace file.
+type Inference struct {
+	// Field is the JSON path of the field in v1.Model, such as "config.precision".
+	Field string
+
+	// Source is the file and key the field was inferred from, such as "config.json:torch_dtype".
+	Source string
+}
+
+func (i Inference) String() string {
+	return i.Field + " from " + i.Source
+}
+
+// Result is a model partially populated from HuggingFace files.
+type Result struct {
+	// Model holds the inferred fields, the other ones are left empty.
+	Model v1.Model
+
+	// Inferred lists the inferred fields, in the order they were set.
+	Inferred []Inference
+
+	// Unmapped lists the sorted model card keys that were not mapped,
+	// and the values of the mapped keys that were skipped, such as "language=code".
+	Unmapped []string
+
+	// Findings lists the inconsistencies of the imported config, which is returned as is,
+	// such as "invalid architectureDetails: numAttentionHeads 24 does not divide hiddenSize 4096".
+	Findings []string
+}
+
+func (r *Result) infer(field, source string) {
+	r.Inferred = append(r.Inferred, Inference{Field: field, Source: source})
+}
+
+// ImportDir imports the config.json of the directory, and its generation_config.json if present.
+func ImportDir(dir string) (*Result, error) {
+	config, err := os.Open(filepath.Join(dir, ConfigFile))
+	if err != nil {
+		return nil, err
+	}
+	defer config.Close()
+
+	var generation io.Reader
+	if f, err := os.Open(filepath.Join(dir, GenerationConfigFile)); err == nil {
+		defer f.Close()
+		generation = f
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return Import(config, generation)
+}
+
+// Import reads a HuggingFace config.json and, when generation is not nil, a generation_config.json.
+// It infers the architecture and its details, the precision from torch_dtype, the quantization from
+// quantization_config, the context length and the default sampling parameters. The architecture
+// details of multimodal models are read from their text_config when missing from the top level.
+// The inconsistencies of the inferred config are listed in the findings of the result.
+func Import(config io.Reader, generation io.Reader) (*Result, error) {
+	cfg, err := decode(config, ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	if text, ok := cfg.object("text_config"); ok {
+		cfg.fallback = &text
+	}
+	r := &Result{}
+	c := &r.Model.Config
+
+	if family, source := cfg.string("model_type"); source != "" {
+		r.Model.Descriptor.Family = family
+		r.infer("descriptor.family", source)
+	}
+
+	details := &v1.ArchitectureDetails{}
+	for _, f := range []struct {
+		field string
+		value *int
+		keys  []string
+	}{
+		{"numLayers", &details.NumLayers, []string{"num_hidden_layers", "n_layer", "num_layers"}},
+		{"hiddenSize", &details.HiddenSize, []string{"hidden_size", "n_embd", "d_model"}},
+		{"numAttentionHeads", &details.NumAttentionHeads, []string{"num_attention_heads", "n_head", "num_heads"}},
+		{"numKeyValueHeads", &details.NumKeyValueHeads, []string{"num_key_value_heads", "multi_query_group_num"}},
+		{"headDim", &details.HeadDim, []string{"head_dim"}},
+		{"vocabSize", &details.VocabSize, []string{"vocab_size"}},
+		{"maxPositionEmbeddings", &details.MaxPositionEmbeddings, []string{"max_position_embeddings", "n_positions", "max_sequence_length", "seq_length"}},
+		{"numExperts", &details.NumExperts, []string{"num_local_experts", "num_experts", "n_routed_experts"}},
+		{"numActiveExperts", &details.NumActiveExperts, []string{"num_experts_per_tok", "moe_topk"}},
+	} {
+		if v, source := cfg.int(f.keys...); source != "" && v > 0 {
+			*f.value = v
+			r.infer("config.architectureDetails."+f.field, source)
+		}
+	}
+	if *details != (v1.ArchitectureDetails{}) {
+		c.ArchitectureDetails = details
+	}
+	if _, source := cfg.int("num_attention_heads", "n_head", "num_heads"); source != "" {
+		c.Architecture = "transformer"
+		r.infer("config.architecture", source)
+	}
+	if n, source := cfg.int("max_position_embeddings", "n_positions", "max_sequence_length", "seq_length"); source != "" && n > 0 {
+		c.Runtime = &v1.ModelRuntime{MaxContextLength: n}
+		r.infer("config.runtime.maxContextLength", source)
+	}
+
+	if dtype, source := cfg.string("torch_dtype", "dtype"); source != "" {
+		if precision, ok := precisions[strings.ToLower(strings.TrimPrefix(dtype, "torch."))]; ok {
+			c.Precision = precision
+			r.infer("config.precision", source)
+		}
+	}
+
+	if q, ok := cfg.object("quantization_config"); ok {
+		if details, source := importQuantization(q); source != "" {
+			c.Quantization = details.Method
+			c.QuantizationDetails = &details
+			r.infer("config.quantization", source)
+			r.infer("config.quantizationDetails", source)
+		}
+	}
+
+	if _, ok := cfg.object("vision_config"); ok {
+		c.Capabilities = &v1.ModelCapabilities{InputTypes: []v1.Modality{v1.TextModality, v1.ImageModality}}
+		r.infer("config.capabilities.inputTypes", ConfigFile+":vision_config")
+	}
+
+	if generation != nil {
+		gen, err := decode(generation, GenerationConfigFile)
+		if err != nil {
+			return nil, err
+		}
+		importSampling(r, gen)
+	}
+
+	if err := c.Validate(); err != nil {
+		r.Findings = findings(err)
+	}
+	return r, nil
+}
+
+// findings lists the errors joined in err.
+func findings(err error) []string {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var list []string
+		for _, e := range joined.Unwrap() {
+			list = append(list, findings(e)...)
+		}
+		return list
+	}
+	return []string{err.Error()}
+}
+
+// precisions maps the torch dtypes to the precisions of the model config.
+var precisions = map[string]string{
+	"float32":  "float32",
+	"float":    "float32",
+	"float16":  "float16",
+	"half":     "float16",
+	"bfloat16": "bfloat16",
+	"float64":  "float64",
+	"double":   "float64",
+	"int8":     "int8",
+	"uint8":    "uint8",
+}
+
+// importQuantization reads a transformers quantization_config, returning the source of its method.
+func importQuantization(q object) (v1.QuantizationDetails, string) {
+	method, source := q.string("quant_method")
+	if source == "" {
+		return v1.QuantizationDetails{}, ""
+	}
+	details := v1.QuantizationDetails{Method: strings.ToLower(method)}
+
+	if bits, src := q.int("bits", "w_bit", "weight_bits"); src != "" && bits > 0 {
+		details.Bits = bits
+	}
+	if load, _ := q.bool("load_in_4bit"); load {
+		details.Bits = 4
+	} else if load, _ := q.bool("load_in_8bit"); load {
+		details.Bits = 8
+	}
+	if details.Method == "fp8" {
+		details.Bits = 8
+	}
+	// a negative group size means per-channel quantization
+	if groupSize, src := q.int("group_size", "q_group_size"); src != "" && groupSize > 0 {
+		details.GroupSize = groupSize
+	}
+	if sym, src := q.bool("sym"); src != "" {
+		details.Symmetric = &sym
+	} else if zeroPoint, src := q.bool("zero_point"); src != "" {
+		symmetric := !zeroPoint
+		details.Symmetric = &symmetric
+	}
+	details.ExcludedModules, _ = q.strings("modules_to_not_convert", "llm_int8_skip_modules", "ignored_layers")
+	// the GPTQ calibration dataset may also be a list of samples
+	details.CalibrationDataset, _ = q.string("dataset")
+	return details, source
+}
+
+// importSampling reads the default sampling parameters of a generation_config.json.
+func importSampling(r *Result, gen object) {
+	sampling := &v1.SamplingParameters{}
+	for _, f := range []struct {
+		field string
+		value **float64
+		key   string
+	}{
+		{"temperature", &sampling.Temperature, "temperature"},
+		{"topP", &sampling.TopP, "top_p"},
+		{"minP", &sampling.MinP, "min_p"},
+		{"repetitionPenalty", &sampling.RepetitionPenalty, "repetition_penalty"},
+	} {
+		if v, source := gen.float(f.key); source != "" {
+			*f.value = &v
+			r.infer("config.runtime.sampling."+f.field, source)
+		}
+	}
+	if v, source := gen.int("top_k"); source != "" && v > 0 {
+		sampling.TopK = &v
+		r.infer("config.runtime.sampling.topK", source)
+	}
+	if *sampling == (v1.SamplingParameters{}) {
+		return
+	}
+	if r.Model.Config.Runtime == nil {
+		r.Model.Config.Runtime = &v1.ModelRuntime{}
+	}
+	r.Model.Config.Runtime.Sampling = sampling
+}
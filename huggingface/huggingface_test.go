@@ -0,0 +1,265 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package huggingface_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/modelpack/model-spec/huggingface"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+const llamaConfig = `{
+  "architectures": ["LlamaForCausalLM"],
+  "model_type": "llama",
+  "hidden_size": 4096,
+  "num_hidden_layers": 32,
+  "num_attention_heads": 32,
+  "num_key_value_heads": 8,
+  "vocab_size": 128256,
+  "max_position_embeddings": 131072,
+  "torch_dtype": "bfloat16",
+  "rope_scaling": null
+}`
+
+const generationConfig = `{
+  "bos_token_id": 128000,
+  "eos_token_id": [128001, 128009],
+  "do_sample": true,
+  "temperature": 0.6,
+  "top_p": 0.9
+}`
+
+func TestImportDir(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		huggingface.ConfigFile:           llamaConfig,
+		huggingface.GenerationConfigFile: generationConfig,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := huggingface.ImportDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := r.Model.Config
+	if r.Model.Descriptor.Family != "llama" || c.Architecture != "transformer" || c.Precision != "bfloat16" {
+		t.Errorf("expected llama transformer in bfloat16, got %q %q in %q", r.Model.Descriptor.Family, c.Architecture, c.Precision)
+	}
+	expected := v1.ArchitectureDetails{NumLayers: 32, HiddenSize: 4096, NumAttentionHeads: 32, NumKeyValueHeads: 8, VocabSize: 128256, MaxPositionEmbeddings: 131072}
+	if c.ArchitectureDetails == nil || *c.ArchitectureDetails != expected {
+		t.Errorf("expected architecture details %+v, got %+v", expected, c.ArchitectureDetails)
+	}
+	if c.Runtime == nil || c.Runtime.MaxContextLength != 131072 || c.Runtime.Sampling == nil ||
+		*c.Runtime.Sampling.Temperature != 0.6 || *c.Runtime.Sampling.TopP != 0.9 || c.Runtime.Sampling.TopK != nil {
+		t.Errorf("expected runtime with context length and sampling, got %+v", c.Runtime)
+	}
+
+	sources := make(map[string]string)
+	for _, i := range r.Inferred {
+		sources[i.Field] = i.Source
+	}
+	for field, source := range map[string]string{
+		"config.precision":                     "config.json:torch_dtype",
+		"config.runtime.maxContextLength":      "config.json:max_position_embeddings",
+		"config.runtime.sampling.topP":         "generation_config.json:top_p",
+		"config.architectureDetails.numLayers": "config.json:num_hidden_layers",
+	} {
+		if sources[field] != source {
+			t.Errorf("expected %s inferred from %s, got %q", field, source, sources[field])
+		}
+	}
+	if _, ok := sources["config.quantization"]; ok {
+		t.Errorf("expected no quantization inferred")
+	}
+
+	// generation_config.json is optional
+	if err := os.Remove(filepath.Join(dir, huggingface.GenerationConfigFile)); err != nil {
+		t.Fatal(err)
+	}
+	if r, err := huggingface.ImportDir(dir); err != nil || r.Model.Config.Runtime.Sampling != nil {
+		t.Errorf("expected no sampling parameters without generation config, got %v", err)
+	}
+}
+
+func TestImport(t *testing.T) {
+	for i, tt := range []struct {
+		config   string
+		check    func(v1.ModelConfig) bool
+		findings int
+		fail     bool
+	}{
+		// GPTQ quantization
+		{
+			config: `{
+  "model_type": "mistral",
+  "num_attention_heads": 32,
+  "hidden_size": 4096,
+  "torch_dtype": "float16",
+  "quantization_config": {"quant_method": "gptq", "bits": 4, "group_size": 128, "sym": true, "dataset": "c4"}
+}`,
+			check: func(c v1.ModelConfig) bool {
+				q := c.QuantizationDetails
+				return c.Quantization == "gptq" && q != nil && q.Bits == 4 && q.GroupSize == 128 &&
+					q.Symmetric != nil && *q.Symmetric && q.CalibrationDataset == "c4"
+			},
+		},
+		// AWQ quantization with zero point and per-channel groups
+		{
+			config: `{
+  "quantization_config": {"quant_method": "awq", "w_bit": 4, "q_group_size": -1, "zero_point": true, "modules_to_not_convert": ["lm_head"]}
+}`,
+			check: func(c v1.ModelConfig) bool {
+				q := c.QuantizationDetails
+				return q != nil && q.Method == "awq" && q.Bits == 4 && q.GroupSize == 0 &&
+					q.Symmetric != nil && !*q.Symmetric && len(q.ExcludedModules) == 1 && c.Architecture == ""
+			},
+		},
+		// bitsandbytes quantization
+		{
+			config: `{"quantization_config": {"quant_method": "bitsandbytes", "load_in_4bit": true, "bnb_4bit_quant_type": "nf4"}}`,
+			check: func(c v1.ModelConfig) bool {
+				return c.QuantizationDetails != nil && c.QuantizationDetails.Bits == 4
+			},
+		},
+		// multimodal model with a text config
+		{
+			config: `{
+  "model_type": "llava",
+  "vision_config": {"hidden_size": 1024, "num_attention_heads": 16},
+  "text_config": {"hidden_size": 4096, "num_attention_heads": 32, "max_position_embeddings": 4096},
+  "torch_dtype": "torch.float16"
+}`,
+			check: func(c v1.ModelConfig) bool {
+				return c.ArchitectureDetails != nil && c.ArchitectureDetails.HiddenSize == 4096 &&
+					c.Runtime != nil && c.Runtime.MaxContextLength == 4096 && c.Precision == "float16" &&
+					c.Capabilities != nil && len(c.Capabilities.InputTypes) == 2
+			},
+		},
+		// gpt2 naming
+		{
+			config: `{"model_type": "gpt2", "n_layer": 12, "n_embd": 768, "n_head": 12, "n_positions": 1024}`,
+			check: func(c v1.ModelConfig) bool {
+				d := c.ArchitectureDetails
+				return d != nil && d.NumLayers == 12 && d.HiddenSize == 768 && d.NumAttentionHeads == 12 && c.Runtime.MaxContextLength == 1024
+			},
+		},
+		// inconsistent architecture is returned with findings
+		{
+			config: `{"hidden_size": 4096, "num_attention_heads": 24}`,
+			check: func(c v1.ModelConfig) bool {
+				return c.ArchitectureDetails != nil && c.ArchitectureDetails.NumAttentionHeads == 24
+			},
+			findings: 1,
+		},
+		// not JSON
+		{config: `model_type: llama`, fail: true},
+	} {
+		r, err := huggingface.Import(strings.NewReader(tt.config), nil)
+		if (err != nil) != tt.fail {
+			t.Errorf("test %d: expected failure %t, got %v", i, tt.fail, err)
+			continue
+		}
+		if err == nil && !tt.check(r.Model.Config) {
+			t.Errorf("test %d: unexpected config %+v", i, r.Model.Config)
+		}
+		if err == nil && len(r.Findings) != tt.findings {
+			t.Errorf("test %d: expected %d findings, got %q", i, tt.findings, r.Findings)
+		}
+	}
+}
+
+const modelCard = `---
+license: other
+license_name: llama3.1 community
+language:
+- en
+- de
+- code
+base_model: meta-llama/Llama-3.1-8B
+base_model_relation: quantized
+datasets:
+- HuggingFaceFW/fineweb
+tags:
+- gguf
+pipeline_tag: text-generation
+---
+
+# Llama 3.1 8B GGUF
+`
+
+func TestImportModelCard(t *testing.T) {
+	r, err := huggingface.ImportModelCard(strings.NewReader(modelCard))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := r.Model.Descriptor
+	if !reflect.DeepEqual(d.Licenses, []string{"LicenseRef-llama3.1-community"}) {
+		t.Errorf("unexpected licenses %v", d.Licenses)
+	}
+	if !reflect.DeepEqual(d.DatasetsURL, []string{"https://huggingface.co/datasets/HuggingFaceFW/fineweb"}) {
+		t.Errorf("unexpected datasets %v", d.DatasetsURL)
+	}
+	expected := []v1.ParentModel{{Reference: "hf.co/meta-llama/llama-3.1-8b", Relationship: v1.RelationshipQuantizedFrom}}
+	if d.Lineage == nil || !reflect.DeepEqual(d.Lineage.Parents, expected) {
+		t.Errorf("expected parents %+v, got %+v", expected, d.Lineage)
+	}
+	if c := r.Model.Config.Capabilities; c == nil || !reflect.DeepEqual(c.Languages, []string{"en", "de"}) {
+		t.Errorf("unexpected capabilities %+v", c)
+	}
+	if !reflect.DeepEqual(r.Unmapped, []string{"language=code", "pipeline_tag", "tags"}) {
+		t.Errorf("unexpected unmapped keys %v", r.Unmapped)
+	}
+
+	for i, tt := range []struct {
+		card     string
+		licenses []string
+		parents  int
+		unmapped []string
+		fail     bool
+	}{
+		{card: "---\nlicense: apache-2.0\nbase_model: [a/b, c/d]\n---\n", licenses: []string{"Apache-2.0"}, parents: 2},
+		{card: "---\r\nlicense: MIT\r\n---\r\n", licenses: []string{"MIT"}},
+		{card: "# No front matter\n"},
+		{card: "---\nlicense: mit\n", fail: true},
+		{card: "---\nbase_model: a/b\nbase_model_relation: fork\n---\n", unmapped: []string{"base_model", "base_model_relation=fork"}},
+		{card: "---\n- license\n---\n", fail: true},
+	} {
+		r, err := huggingface.ImportModelCard(strings.NewReader(tt.card))
+		if (err != nil) != tt.fail {
+			t.Errorf("test %d: expected failure %t, got %v", i, tt.fail, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		d := r.Model.Descriptor
+		if !reflect.DeepEqual(d.Licenses, tt.licenses) || (d.Lineage != nil) != (tt.parents > 0) || d.Lineage != nil && len(d.Lineage.Parents) != tt.parents {
+			t.Errorf("test %d: unexpected descriptor %+v", i, d)
+		}
+		if !reflect.DeepEqual(r.Unmapped, tt.unmapped) {
+			t.Errorf("test %d: expected unmapped keys %v, got %v", i, tt.unmapped, r.Unmapped)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package huggingface
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// ModelCardFile is the name of the HuggingFace model card.
+const ModelCardFile = "README.md"
+
+// DatasetsURL is the URL prefix of the HuggingFace datasets.
+const DatasetsURL = "https://huggingface.co/datasets/"
+
+// ReferencePrefix is prepended to the HuggingFace ids of the base models to reference them.
+const ReferencePrefix = "hf.co/"
+
+// spdxLicenses maps the HuggingFace license ids to SPDX license identifiers.
+// Other ids are mapped to a LicenseRef.
+var spdxLicenses = map[string]string{
+	"apache-2.0":      "Apache-2.0",
+	"mit":             "MIT",
+	"bsd-2-clause":    "BSD-2-Clause",
+	"bsd-3-clause":    "BSD-3-Clause",
+	"bsl-1.0":         "BSL-1.0",
+	"cc0-1.0":         "CC0-1.0",
+	"cc-by-4.0":       "CC-BY-4.0",
+	"cc-by-sa-4.0":    "CC-BY-SA-4.0",
+	"cc-by-nc-4.0":    "CC-BY-NC-4.0",
+	"cc-by-nc-sa-4.0": "CC-BY-NC-SA-4.0",
+	"cc-by-nc-nd-4.0": "CC-BY-NC-ND-4.0",
+	"gpl-2.0":         "GPL-2.0-only",
+	"gpl-3.0":         "GPL-3.0-only",
+	"lgpl-3.0":        "LGPL-3.0-only",
+	"agpl-3.0":        "AGPL-3.0-only",
+	"mpl-2.0":         "MPL-2.0",
+	"epl-2.0":         "EPL-2.0",
+	"artistic-2.0":    "Artistic-2.0",
+	"unlicense":       "Unlicense",
+	"wtfpl":           "WTFPL",
+}
+
+// relationships maps the base_model_relation values to lineage relationships.
+var relationships = map[string]v1.Relationship{
+	"finetune":  v1.RelationshipFinetune,
+	"adapter":   v1.RelationshipAdapter,
+	"quantized": v1.RelationshipQuantizedFrom,
+	"merge":     v1.RelationshipMerged,
+}
+
+var (
+	languageRegexp   = regexp.MustCompile(`^[a-z]{2}$`)
+	licenseRefRegexp = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+)
+
+// ImportModelCard reads the YAML front matter of a HuggingFace model card. The license is mapped to an
+// SPDX identifier, or a LicenseRef for custom licenses, the two letter language codes to the capabilities,
+// the datasets to their URL, and the base models to the parents of the lineage, referenced as hf.co/<id>.
+// The other keys and values are listed in Result.Unmapped. A model card without front matter imports nothing.
+func ImportModelCard(r io.Reader) (*Result, error) {
+	matter, err := frontMatter(r)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]any
+	if err := yaml.Unmarshal(matter, &values); err != nil {
+		return nil, fmt.Errorf("invalid %s front matter: %w", ModelCardFile, err)
+	}
+
+	res := &Result{}
+	d := &res.Model.Descriptor
+	source := func(key string) string { return ModelCardFile + ":" + key }
+	mapped := make(map[string]bool)
+
+	if license, ok := values["license"].(string); ok && license != "" {
+		license = strings.ToLower(license)
+		if name, ok := values["license_name"].(string); ok && license == "other" && name != "" {
+			license = name
+			mapped["license_name"] = true
+		}
+		if spdx, ok := spdxLicenses[license]; ok {
+			d.Licenses = []string{spdx}
+		} else {
+			d.Licenses = []string{"LicenseRef-" + strings.Trim(licenseRefRegexp.ReplaceAllString(license, "-"), "-")}
+		}
+		mapped["license"] = true
+		res.infer("descriptor.licenses", source("license"))
+	}
+
+	if languages := stringList(values["language"]); len(languages) > 0 {
+		mapped["language"] = true
+		var codes []string
+		for _, lang := range languages {
+			if languageRegexp.MatchString(lang) {
+				codes = append(codes, lang)
+			} else {
+				res.Unmapped = append(res.Unmapped, "language="+lang)
+			}
+		}
+		if len(codes) > 0 {
+			res.Model.Config.Capabilities = &v1.ModelCapabilities{Languages: codes}
+			res.infer("config.capabilities.languages", source("language"))
+		}
+	}
+
+	if datasets := stringList(values["datasets"]); len(datasets) > 0 {
+		for _, dataset := range datasets {
+			d.DatasetsURL = append(d.DatasetsURL, DatasetsURL+dataset)
+		}
+		mapped["datasets"] = true
+		res.infer("descriptor.datasetsURL", source("datasets"))
+	}
+
+	if bases := stringList(values["base_model"]); len(bases) > 0 {
+		relationship := v1.RelationshipFinetune
+		if len(bases) > 1 {
+			relationship = v1.RelationshipMerged
+		}
+		if relation, ok := values["base_model_relation"].(string); ok {
+			relationship = relationships[relation]
+			mapped["base_model_relation"] = true
+			if relationship == "" {
+				// the base models are left unmapped rather than given a guessed relationship
+				res.Unmapped = append(res.Unmapped, "base_model_relation="+relation)
+			}
+		}
+		if relationship != "" {
+			d.Lineage = &v1.ModelLineage{}
+			for _, base := range bases {
+				d.Lineage.Parents = append(d.Lineage.Parents, v1.ParentModel{
+					Reference:    ReferencePrefix + strings.ToLower(base),
+					Relationship: relationship,
+				})
+			}
+			mapped["base_model"] = true
+			res.infer("descriptor.lineage", source("base_model"))
+		}
+	}
+
+	for key := range values {
+		if !mapped[key] {
+			res.Unmapped = append(res.Unmapped, key)
+		}
+	}
+	sort.Strings(res.Unmapped)
+	return res, nil
+}
+
+// frontMatter returns the YAML between the leading `---` line of the model card and the next one.
+func frontMatter(r io.Reader) ([]byte, error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	if !s.Scan() || strings.TrimSpace(s.Text()) != "---" {
+		return nil, s.Err()
+	}
+	var matter bytes.Buffer
+	for s.Scan() {
+		if strings.TrimSpace(s.Text()) == "---" {
+			return matter.Bytes(), nil
+		}
+		matter.WriteString(s.Text())
+		matter.WriteByte('\n')
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("invalid %s: unterminated front matter", ModelCardFile)
+}
+
+// stringList returns the strings of a YAML value that is a string or a list.
+func stringList(v any) []string {
+	switch v := v.(type) {
+	case string:
+		if v != "" {
+			return []string{v}
+		}
+	case []any:
+		var list []string
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package huggingface
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// object is a JSON object of a HuggingFace file whose getters return the value of the first
+// present key along with its source, such as "config.json:torch_dtype", or an empty source if absent.
+type object struct {
+	file   string
+	prefix string
+	values map[string]json.RawMessage
+
+	// fallback is looked up for the keys missing from the object, such as text_config.
+	fallback *object
+}
+
+func decode(r io.Reader, file string) (object, error) {
+	o := object{file: file}
+	if err := json.NewDecoder(r).Decode(&o.values); err != nil {
+		return o, fmt.Errorf("invalid %s: %w", file, err)
+	}
+	return o, nil
+}
+
+func (o object) lookup(keys []string, v any) string {
+	for _, key := range keys {
+		if raw, ok := o.values[key]; ok && string(raw) != "null" && json.Unmarshal(raw, v) == nil {
+			return o.file + ":" + o.prefix + key
+		}
+	}
+	if o.fallback != nil {
+		return o.fallback.lookup(keys, v)
+	}
+	return ""
+}
+
+func (o object) string(keys ...string) (string, string) {
+	var s string
+	source := o.lookup(keys, &s)
+	if s == "" {
+		return "", ""
+	}
+	return s, source
+}
+
+func (o object) float(keys ...string) (float64, string) {
+	var f float64
+	source := o.lookup(keys, &f)
+	return f, source
+}
+
+func (o object) int(keys ...string) (int, string) {
+	f, source := o.float(keys...)
+	if source == "" || f != math.Trunc(f) {
+		return 0, ""
+	}
+	return int(f), source
+}
+
+func (o object) bool(keys ...string) (bool, string) {
+	var b bool
+	source := o.lookup(keys, &b)
+	return b, source
+}
+
+func (o object) strings(keys ...string) ([]string, string) {
+	var s []string
+	source := o.lookup(keys, &s)
+	return s, source
+}
+
+func (o object) object(key string) (object, bool) {
+	nested := object{file: o.file, prefix: o.prefix + key + "."}
+	raw, ok := o.values[key]
+	if !ok || json.Unmarshal(raw, &nested.values) != nil || nested.values == nil {
+		return nested, false
+	}
+	return nested, true
+}
@@ -0,0 +1,197 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package layout
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// MaxManifestSize bounds the size of index and manifest documents read into memory.
+const MaxManifestSize = 4 << 20
+
+// Layout is a read-only view of an OCI image layout.
+type Layout struct {
+	fsys   fs.FS
+	closer io.Closer
+}
+
+// Open opens the OCI image layout stored in the given directory.
+func Open(dir string) (*Layout, error) {
+	return New(os.DirFS(dir))
+}
+
+// New returns the OCI image layout rooted at fsys.
+func New(fsys fs.FS) (*Layout, error) {
+	buf, err := fs.ReadFile(fsys, ocispec.ImageLayoutFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ocispec.ImageLayoutFile, err)
+	}
+	var layout ocispec.ImageLayout
+	if err := json.Unmarshal(buf, &layout); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ocispec.ImageLayoutFile, err)
+	}
+	if layout.Version != ocispec.ImageLayoutVersion {
+		return nil, fmt.Errorf("unsupported image layout version %q", layout.Version)
+	}
+	return &Layout{fsys: fsys}, nil
+}
+
+// Close releases the resources held by the layout, such as the file of a tarball.
+func (l *Layout) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
+// Index returns the image index of the layout.
+func (l *Layout) Index() (ocispec.Index, error) {
+	var index ocispec.Index
+	buf, err := fs.ReadFile(l.fsys, ocispec.ImageIndexFile)
+	if err != nil {
+		return index, fmt.Errorf("failed to read %s: %w", ocispec.ImageIndexFile, err)
+	}
+	if err := json.Unmarshal(buf, &index); err != nil {
+		return index, fmt.Errorf("failed to parse %s: %w", ocispec.ImageIndexFile, err)
+	}
+	return index, nil
+}
+
+// Resolve returns the manifest descriptor in the index matching ref.
+// The ref is either a digest or the value of the `org.opencontainers.image.ref.name` annotation.
+// An empty ref resolves to the only manifest of the index.
+func (l *Layout) Resolve(ref string) (ocispec.Descriptor, error) {
+	index, err := l.Index()
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	if ref == "" {
+		if len(index.Manifests) != 1 {
+			return ocispec.Descriptor{}, fmt.Errorf("index has %d manifests, a reference is required", len(index.Manifests))
+		}
+		return index.Manifests[0], nil
+	}
+	for _, desc := range index.Manifests {
+		if desc.Digest.String() == ref || desc.Annotations[ocispec.AnnotationRefName] == ref {
+			return desc, nil
+		}
+	}
+	return ocispec.Descriptor{}, fmt.Errorf("reference %q not found in index", ref)
+}
+
+// Manifest reads and verifies the image manifest referenced by desc.
+func (l *Layout) Manifest(desc ocispec.Descriptor) (ocispec.Manifest, error) {
+	var manifest ocispec.Manifest
+	if desc.Size > MaxManifestSize {
+		return manifest, fmt.Errorf("manifest %s is too large: %d bytes", desc.Digest, desc.Size)
+	}
+	buf, err := l.ReadBlob(desc)
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(buf, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to parse manifest %s: %w", desc.Digest, err)
+	}
+	return manifest, nil
+}
+
+// ReadBlob reads and verifies the whole blob referenced by desc.
+func (l *Layout) ReadBlob(desc ocispec.Descriptor) ([]byte, error) {
+	rc, err := l.Blob(desc)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+// Blob opens the blob referenced by desc.
+// The returned reader verifies the size and digest of the blob,
+// reading it to the end returns an error instead of io.EOF if they do not match.
+func (l *Layout) Blob(desc ocispec.Descriptor) (io.ReadCloser, error) {
+	f, err := l.OpenBlob(desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+	return &verifyReader{
+		f:        f,
+		digest:   desc.Digest,
+		size:     desc.Size,
+		verifier: desc.Digest.Verifier(),
+	}, nil
+}
+
+// OpenBlob opens the blob with the given digest without verifying its content.
+func (l *Layout) OpenBlob(dgst digest.Digest) (fs.File, error) {
+	if err := dgst.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid blob digest %q: %w", dgst, err)
+	}
+	f, err := l.fsys.Open(BlobPath(dgst))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob %s: %w", dgst, err)
+	}
+	return f, nil
+}
+
+// BlobPath returns the path of the blob with the given digest relative to the layout root.
+func BlobPath(dgst digest.Digest) string {
+	return path.Join(ocispec.ImageBlobsDir, dgst.Algorithm().String(), dgst.Encoded())
+}
+
+// verifyReader checks the size and digest of the content read through it.
+type verifyReader struct {
+	f        fs.File
+	digest   digest.Digest
+	size     int64
+	read     int64
+	verifier digest.Verifier
+}
+
+func (r *verifyReader) Read(p []byte) (int, error) {
+	n, err := r.f.Read(p)
+	r.read += int64(n)
+	if r.read > r.size {
+		return n, fmt.Errorf("blob %s is larger than the expected size %d", r.digest, r.size)
+	}
+	// writes to a digest.Verifier never fail
+	_, _ = r.verifier.Write(p[:n])
+
+	if errors.Is(err, io.EOF) {
+		if r.read != r.size {
+			return n, fmt.Errorf("blob %s has size %d, expected %d", r.digest, r.read, r.size)
+		}
+		if !r.verifier.Verified() {
+			return n, fmt.Errorf("blob %s does not match its digest", r.digest)
+		}
+	}
+	return n, err
+}
+
+func (r *verifyReader) Close() error {
+	return r.f.Close()
+}
@@ -0,0 +1,110 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package layout
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+)
+
+// OpenTarball opens the OCI image layout stored in an uncompressed tar archive.
+// The entries are read in place, the caller must Close the layout when done.
+func OpenTarball(file string) (*Layout, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	fsys, err := newTarFS(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to index tarball %s: %w", file, err)
+	}
+	l, err := New(fsys)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	l.closer = f
+	return l, nil
+}
+
+// tarFS is a read-only fs.FS serving the regular files of a tar archive from their offsets.
+type tarFS struct {
+	r       io.ReaderAt
+	entries map[string]tarEntry
+}
+
+type tarEntry struct {
+	hdr    *tar.Header
+	offset int64
+}
+
+func newTarFS(r io.ReaderAt) (*tarFS, error) {
+	fsys := &tarFS{r: r, entries: make(map[string]tarEntry)}
+	counter := &countReader{r: io.NewSectionReader(r, 0, 1<<63-1)}
+	tr := tar.NewReader(counter)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return fsys, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		// the tar reader consumes the headers exactly, the content starts at the current offset
+		fsys.entries[path.Clean(hdr.Name)] = tarEntry{hdr: hdr, offset: counter.n}
+	}
+}
+
+func (t *tarFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	e, ok := t.entries[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &tarFile{SectionReader: io.NewSectionReader(t.r, e.offset, e.hdr.Size), info: e.hdr.FileInfo()}, nil
+}
+
+type tarFile struct {
+	*io.SectionReader
+	info fs.FileInfo
+}
+
+func (f *tarFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+func (f *tarFile) Close() error { return nil }
+
+type countReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
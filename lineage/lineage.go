@@ -0,0 +1,223 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package lineage builds the ancestry graph of a set of models from the lineage of their configs.
+package lineage
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+)
+
+// Model is a model config along with the identifiers of its manifest.
+type Model struct {
+	// Digest is the digest of the manifest of the model.
+	Digest digest.Digest
+
+	// Reference is the OCI reference of the model, if any.
+	Reference string
+
+	// Config is the config of the model.
+	Config v1.Model
+}
+
+// Node is a model of the ancestry graph.
+type Node struct {
+	// ID identifies the node: the manifest digest when known, otherwise the reference.
+	ID string
+
+	// Model is the model, or nil for a parent referenced by a config but absent from the set.
+	Model *Model
+
+	// Parents lists the edges to the models this model derives from, in lineage order.
+	Parents []Edge
+}
+
+// Edge links a model to one of its parents.
+type Edge struct {
+	Parent       *Node
+	Relationship v1.Relationship
+}
+
+// Graph is the ancestry graph of a set of models.
+type Graph struct {
+	nodes map[string]*Node
+	order []*Node
+}
+
+// CycleError is returned by Build when models derive from themselves.
+type CycleError struct {
+	// Path lists the IDs of the nodes of the cycle, starting and ending with the same node.
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return "lineage cycle: " + strings.Join(e.Path, " -> ")
+}
+
+// Build links the models to their parents, matched by manifest digest, or by reference when the
+// parent has no digest. It fails if two models have the same identifiers or if the lineage is cyclic.
+func Build(models []Model) (*Graph, error) {
+	g := &Graph{nodes: make(map[string]*Node)}
+	byReference := make(map[string]*Node)
+
+	for i := range models {
+		m := &models[i]
+		if m.Digest == "" && m.Reference == "" {
+			return nil, fmt.Errorf("model %d: digest or reference is required", i)
+		}
+		n := &Node{ID: id(m.Digest, m.Reference), Model: m}
+		if _, ok := g.nodes[n.ID]; ok {
+			return nil, fmt.Errorf("model %d: duplicate model %s", i, n.ID)
+		}
+		if m.Reference != "" {
+			if _, ok := byReference[m.Reference]; ok {
+				return nil, fmt.Errorf("model %d: duplicate reference %s", i, m.Reference)
+			}
+			byReference[m.Reference] = n
+		}
+		g.add(n)
+	}
+
+	for _, n := range g.order {
+		if n.Model == nil || n.Model.Config.Descriptor.Lineage == nil {
+			continue
+		}
+		for _, p := range n.Model.Config.Descriptor.Lineage.Parents {
+			parent := g.nodes[id(p.Digest, p.Reference)]
+			if parent == nil && p.Digest == "" {
+				parent = byReference[p.Reference]
+			}
+			if parent == nil {
+				parent = &Node{ID: id(p.Digest, p.Reference)}
+				g.add(parent)
+			}
+			n.Parents = append(n.Parents, Edge{Parent: parent, Relationship: p.Relationship})
+		}
+	}
+
+	if cycle := g.findCycle(); cycle != nil {
+		return nil, &CycleError{Path: cycle}
+	}
+	return g, nil
+}
+
+func id(dgst digest.Digest, reference string) string {
+	if dgst != "" {
+		return dgst.String()
+	}
+	return reference
+}
+
+func (g *Graph) add(n *Node) {
+	g.nodes[n.ID] = n
+	g.order = append(g.order, n)
+}
+
+// Nodes returns the nodes of the graph: the models in order, then the parents absent from the set.
+func (g *Graph) Nodes() []*Node {
+	return g.order
+}
+
+// Node returns the node with the given ID, or nil.
+func (g *Graph) Node(id string) *Node {
+	return g.nodes[id]
+}
+
+// Ancestors returns the ancestors of the node with the given ID, nearest first.
+func (g *Graph) Ancestors(id string) ([]*Node, error) {
+	n := g.nodes[id]
+	if n == nil {
+		return nil, fmt.Errorf("unknown model %s", id)
+	}
+
+	var ancestors []*Node
+	seen := map[*Node]bool{n: true}
+	queue := []*Node{n}
+	for len(queue) > 0 {
+		n, queue = queue[0], queue[1:]
+		for _, e := range n.Parents {
+			if !seen[e.Parent] {
+				seen[e.Parent] = true
+				ancestors = append(ancestors, e.Parent)
+				queue = append(queue, e.Parent)
+			}
+		}
+	}
+	return ancestors, nil
+}
+
+// Roots returns the nodes without parents, such as base models.
+func (g *Graph) Roots() []*Node {
+	var roots []*Node
+	for _, n := range g.order {
+		if len(n.Parents) == 0 {
+			roots = append(roots, n)
+		}
+	}
+	return roots
+}
+
+// findCycle returns the IDs of the nodes of a cycle, or nil if the graph is acyclic.
+func (g *Graph) findCycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[*Node]int)
+	var stack []*Node
+
+	var visit func(n *Node) []string
+	visit = func(n *Node) []string {
+		state[n] = visiting
+		stack = append(stack, n)
+		for _, e := range n.Parents {
+			switch state[e.Parent] {
+			case visiting:
+				var path []string
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == e.Parent {
+						for _, s := range stack[i:] {
+							path = append(path, s.ID)
+						}
+						break
+					}
+				}
+				return append(path, e.Parent.ID)
+			case unvisited:
+				if cycle := visit(e.Parent); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[n] = done
+		return nil
+	}
+
+	for _, n := range g.order {
+		if state[n] == unvisited {
+			if cycle := visit(n); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
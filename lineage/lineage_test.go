@@ -0,0 +1,129 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lineage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/modelpack/model-spec/lineage"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+)
+
+func model(name string, parents ...v1.ParentModel) lineage.Model {
+	m := lineage.Model{Digest: digest.FromString(name), Reference: "example.com/models/" + name}
+	m.Config.Descriptor.Name = name
+	if len(parents) > 0 {
+		m.Config.Descriptor.Lineage = &v1.ModelLineage{Parents: parents}
+	}
+	return m
+}
+
+func byDigest(name string, r v1.Relationship) v1.ParentModel {
+	return v1.ParentModel{Digest: digest.FromString(name), Relationship: r}
+}
+
+func byReference(name string, r v1.Relationship) v1.ParentModel {
+	return v1.ParentModel{Reference: "example.com/models/" + name, Relationship: r}
+}
+
+func names(nodes []*lineage.Node) []string {
+	var names []string
+	for _, n := range nodes {
+		if n.Model != nil {
+			names = append(names, n.Model.Config.Descriptor.Name)
+		} else {
+			names = append(names, n.ID)
+		}
+	}
+	return names
+}
+
+func TestAncestors(t *testing.T) {
+	g, err := lineage.Build([]lineage.Model{
+		model("base"),
+		model("instruct", byDigest("base", v1.RelationshipFinetune)),
+		model("instruct-q4", byReference("instruct", v1.RelationshipQuantizedFrom)),
+		model("lora", byDigest("instruct", v1.RelationshipAdapter)),
+		model("merge", byDigest("lora", v1.RelationshipMerged), byReference("other", v1.RelationshipMerged)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		expected []string
+	}{
+		{name: "base"},
+		{name: "instruct-q4", expected: []string{"instruct", "base"}},
+		{name: "merge", expected: []string{"lora", "example.com/models/other", "instruct", "base"}},
+	} {
+		ancestors, err := g.Ancestors(digest.FromString(tt.name).String())
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		got := names(ancestors)
+		if len(got) != len(tt.expected) {
+			t.Errorf("%s: expected ancestors %v, got %v", tt.name, tt.expected, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.expected[i] {
+				t.Errorf("%s: expected ancestors %v, got %v", tt.name, tt.expected, got)
+				break
+			}
+		}
+	}
+
+	if roots := names(g.Roots()); len(roots) != 2 || roots[0] != "base" || roots[1] != "example.com/models/other" {
+		t.Errorf("expected roots [base example.com/models/other], got %v", roots)
+	}
+	if n := g.Node(digest.FromString("lora").String()); n == nil || n.Parents[0].Relationship != v1.RelationshipAdapter {
+		t.Errorf("expected lora to be an adapter of instruct")
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	for i, tt := range []struct {
+		models []lineage.Model
+		cycle  bool
+	}{
+		{
+			models: []lineage.Model{model("self", byDigest("self", v1.RelationshipFinetune))},
+			cycle:  true,
+		},
+		{
+			models: []lineage.Model{
+				model("a", byDigest("c", v1.RelationshipDistilled)),
+				model("b", byReference("a", v1.RelationshipFinetune)),
+				model("c", byDigest("b", v1.RelationshipQuantizedFrom)),
+			},
+			cycle: true,
+		},
+		{models: []lineage.Model{model("a"), model("a")}},
+		{models: []lineage.Model{{}}},
+	} {
+		_, err := lineage.Build(tt.models)
+		var cycle *lineage.CycleError
+		if err == nil || errors.As(err, &cycle) != tt.cycle {
+			t.Errorf("test %d: expected cycle %t, got %v", i, tt.cycle, err)
+		}
+	}
+}
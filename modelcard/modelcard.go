@@ -0,0 +1,270 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package modelcard renders a model and its layers as a human-readable model card,
+// in Markdown or plain text, using templates that can be replaced.
+package modelcard
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Format is a built-in model card format.
+type Format string
+
+const (
+	// FormatMarkdown renders the model card as a Markdown document.
+	FormatMarkdown Format = "markdown"
+
+	// FormatText renders the model card as aligned plain text.
+	FormatText Format = "text"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// Card is the data given to the templates.
+type Card struct {
+	Model v1.Model
+
+	// Layers are the manifest layers listed in the file inventory, if any.
+	Layers []ocispec.Descriptor
+}
+
+// Field is a named value of a model card table.
+type Field struct {
+	// Name is the JSON path of the field, relative to its section, such as "runtime.maxContextLength".
+	Name  string
+	Value string
+}
+
+// File is a layer of the file inventory.
+type File struct {
+	// Path is the `org.cncf.model.filepath` annotation of the layer, or its digest.
+	Path string
+	Size int64
+}
+
+// FileGroup lists the layers of a media type.
+type FileGroup struct {
+	MediaType string
+	Files     []File
+
+	// Size is the total size of the files.
+	Size int64
+}
+
+// Title returns the title of the model, or its name, or "Model".
+func (c Card) Title() string {
+	switch d := c.Model.Descriptor; {
+	case d.Title != "":
+		return d.Title
+	case d.Name != "":
+		return d.Name
+	default:
+		return "Model"
+	}
+}
+
+// Descriptor returns the set fields of the descriptor, except its description.
+func (c Card) Descriptor() []Field {
+	return collect(reflect.ValueOf(c.Model.Descriptor), "", map[string]bool{"description": true})
+}
+
+// Capabilities returns the set fields of the capabilities.
+func (c Card) Capabilities() []Field {
+	if c.Model.Config.Capabilities == nil {
+		return nil
+	}
+	return collect(reflect.ValueOf(*c.Model.Config.Capabilities), "", nil)
+}
+
+// Config returns the set fields of the config, except its capabilities.
+func (c Card) Config() []Field {
+	return collect(reflect.ValueOf(c.Model.Config), "", map[string]bool{"capabilities": true})
+}
+
+// Files returns the layers grouped by media type, sorted by media type, in manifest order within a group.
+func (c Card) Files() []FileGroup {
+	groups := make(map[string]*FileGroup)
+	for _, layer := range c.Layers {
+		g, ok := groups[layer.MediaType]
+		if !ok {
+			g = &FileGroup{MediaType: layer.MediaType}
+			groups[layer.MediaType] = g
+		}
+		path := layer.Annotations[v1.AnnotationFilepath]
+		if path == "" {
+			path = layer.Digest.String()
+		}
+		g.Files = append(g.Files, File{Path: path, Size: layer.Size})
+		g.Size += layer.Size
+	}
+
+	files := make([]FileGroup, 0, len(groups))
+	for _, g := range groups {
+		files = append(files, *g)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].MediaType < files[j].MediaType })
+	return files
+}
+
+// Size returns the total size of the layers.
+func (c Card) Size() int64 {
+	var size int64
+	for _, layer := range c.Layers {
+		size += layer.Size
+	}
+	return size
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// collect flattens the set fields of a struct, named by their JSON path. Nested structs are flattened,
+// lists are joined with commas and lists of structs list the key=value pairs of each element.
+func collect(v reflect.Value, prefix string, skip map[string]bool) []Field {
+	var fields []Field
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "" || name == "-" || skip[prefix+name] {
+			continue
+		}
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct && fv.Type() != timeType {
+			fields = append(fields, collect(fv, prefix+name+".", skip)...)
+			continue
+		}
+		if value := format(fv); value != "" {
+			fields = append(fields, Field{Name: prefix + name, Value: value})
+		}
+	}
+	return fields
+}
+
+// format returns the value as a string, or an empty string for zero values.
+func format(v reflect.Value) string {
+	switch {
+	case v.Type() == timeType:
+		if t := v.Interface().(time.Time); !t.IsZero() {
+			return t.Format(time.RFC3339)
+		}
+		return ""
+	case v.Kind() == reflect.Slice:
+		if v.Len() == 0 {
+			return ""
+		}
+		items := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item := v.Index(i)
+			if item.Kind() == reflect.Struct {
+				var pairs []string
+				for _, f := range collect(item, "", nil) {
+					pairs = append(pairs, f.Name+"="+f.Value)
+				}
+				items = append(items, strings.Join(pairs, " "))
+			} else {
+				items = append(items, format(item))
+			}
+		}
+		if v.Index(0).Kind() == reflect.Struct {
+			return strings.Join(items, "; ")
+		}
+		return strings.Join(items, ", ")
+	case v.Kind() == reflect.Bool:
+		// booleans are optional pointers, so false is a set value
+		return fmt.Sprint(v.Bool())
+	case v.IsZero():
+		return ""
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// Funcs returns the functions available to the templates:
+//
+//	size     formats a number of bytes with binary prefixes, such as "4.7 GiB"
+//	markdown escapes a value for a Markdown table cell
+//	oneline  replaces the line breaks and tabs of a value with spaces
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"size":     size,
+		"markdown": escapeMarkdown,
+		"oneline":  oneline,
+	}
+}
+
+// New parses a model card template, with the functions of Funcs.
+func New(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(Funcs()).Parse(text)
+}
+
+// Template returns the built-in template of a format.
+func Template(format Format) (*template.Template, error) {
+	text, err := templates.ReadFile("templates/" + string(format) + ".tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("unknown model card format %q", format)
+	}
+	return New(string(format), string(text))
+}
+
+// Render executes the template with the card. The tab-separated cells of consecutive lines are aligned,
+// which the plain text template uses for its tables.
+func Render(w io.Writer, tmpl *template.Template, card Card) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if err := tmpl.Execute(tw, card); err != nil {
+		return err
+	}
+	return tw.Flush()
+}
+
+func size(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit && exp < 5; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\t", " ").Replace(s)
+}
+
+func oneline(s string) string {
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ").Replace(s)
+}
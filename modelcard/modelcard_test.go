@@ -0,0 +1,163 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package modelcard_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelpack/model-spec/modelcard"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func card() modelcard.Card {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	reasoning := false
+	return modelcard.Card{
+		Model: v1.Model{
+			Descriptor: v1.ModelDescriptor{
+				CreatedAt:   &created,
+				Name:        "llama3-8b-instruct",
+				Family:      "llama3",
+				Licenses:    []string{"Apache-2.0"},
+				Description: "An instruction tuned model.",
+				Lineage: &v1.ModelLineage{Parents: []v1.ParentModel{
+					{Reference: "registry.example.com/models/llama3:8b", Relationship: v1.RelationshipFinetune},
+				}},
+			},
+			Config: v1.ModelConfig{
+				Architecture: "transformer",
+				Format:       "safetensors",
+				ParamSize:    "8b",
+				Runtime:      &v1.ModelRuntime{MaxContextLength: 8192},
+				Capabilities: &v1.ModelCapabilities{
+					InputTypes: []v1.Modality{v1.TextModality, v1.ImageModality},
+					Reasoning:  &reasoning,
+				},
+			},
+		},
+		Layers: []ocispec.Descriptor{
+			v1.NewLayerDescriptor(v1.MediaTypeModelWeightRaw, digest.FromString("a"), 5<<30, "model-00001.safetensors"),
+			v1.NewLayerDescriptor(v1.MediaTypeModelDoc, digest.FromString("b"), 512, "README | notes.md"),
+			v1.NewLayerDescriptor(v1.MediaTypeModelWeightRaw, digest.FromString("c"), 3<<29, "model-00002.safetensors"),
+		},
+	}
+}
+
+func TestFields(t *testing.T) {
+	c := card()
+	for _, tt := range []struct {
+		name     string
+		fields   []modelcard.Field
+		expected []string
+	}{
+		{
+			name:   "descriptor",
+			fields: c.Descriptor(),
+			expected: []string{
+				"createdAt=2025-01-02T03:04:05Z",
+				"family=llama3",
+				"name=llama3-8b-instruct",
+				"licenses=Apache-2.0",
+				"lineage.parents=reference=registry.example.com/models/llama3:8b relationship=finetune",
+			},
+		},
+		{name: "capabilities", fields: c.Capabilities(), expected: []string{"inputTypes=text, image", "reasoning=false"}},
+		{
+			name:     "config",
+			fields:   c.Config(),
+			expected: []string{"architecture=transformer", "format=safetensors", "paramSize=8b", "runtime.maxContextLength=8192"},
+		},
+	} {
+		var got []string
+		for _, f := range tt.fields {
+			got = append(got, f.Name+"="+f.Value)
+		}
+		if strings.Join(got, "\n") != strings.Join(tt.expected, "\n") {
+			t.Errorf("%s: expected fields %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+
+	files := c.Files()
+	if len(files) != 2 || files[1].MediaType != v1.MediaTypeModelWeightRaw || len(files[1].Files) != 2 ||
+		files[1].Files[1].Path != "model-00002.safetensors" || files[1].Size != 5<<30+3<<29 {
+		t.Errorf("unexpected files %+v", files)
+	}
+}
+
+func TestRender(t *testing.T) {
+	for _, tt := range []struct {
+		format   modelcard.Format
+		expected []string
+	}{
+		{
+			format: modelcard.FormatMarkdown,
+			expected: []string{
+				"# llama3-8b-instruct\n\nAn instruction tuned model.\n\n## Descriptor\n",
+				"| paramSize | 8b |\n",
+				"## Files\n\nTotal size: 6.5 GiB\n",
+				"### `application/vnd.cncf.model.weight.v1.raw`\n",
+				"| README \\| notes.md | 512 B |\n",
+				"| model-00001.safetensors | 5.0 GiB |\n",
+			},
+		},
+		{
+			format: modelcard.FormatText,
+			expected: []string{
+				"llama3-8b-instruct\n\nAn instruction tuned model.\n\nDESCRIPTOR\n",
+				"  paramSize                 8b\n",
+				"FILES (6.5 GiB)\n",
+				"    model-00002.safetensors                          1.5 GiB\n",
+			},
+		},
+	} {
+		tmpl, err := modelcard.Template(tt.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := modelcard.Render(&buf, tmpl, card()); err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range tt.expected {
+			if !strings.Contains(buf.String(), s) {
+				t.Errorf("%s: expected %q in\n%s", tt.format, s, buf.String())
+			}
+		}
+	}
+
+	if _, err := modelcard.Template("html"); err == nil {
+		t.Errorf("expected failure for an unknown format")
+	}
+
+	// user templates have the same functions
+	tmpl, err := modelcard.New("custom", `{{.Title}}: {{range .Files}}{{.MediaType}} {{size .Size}} {{end}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := modelcard.Render(&buf, tmpl, modelcard.Card{Layers: card().Layers[1:2]}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "Model: " + v1.MediaTypeModelDoc + " 512 B "; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
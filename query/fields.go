@@ -0,0 +1,169 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+// kind is the type of a model field as seen by the query language.
+type kind int
+
+const (
+	kindString kind = iota
+	kindList
+	kindModalities
+	kindParamSize
+	kindTime
+	kindBool
+	kindNumber
+)
+
+func (k kind) String() string {
+	return [...]string{"string", "list", "modality list", "param size", "time", "boolean", "number"}[k]
+}
+
+// field is a queryable leaf of v1.Model.
+type field struct {
+	path  string
+	index []int
+	kind  kind
+}
+
+// paramSizePath is the field holding a v1.ParseParamSize value.
+const paramSizePath = "config.paramSize"
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	modalityType = reflect.TypeOf(v1.Modality(""))
+)
+
+// modalities lists the values accepted by the modality list fields.
+var modalities = []v1.Modality{
+	v1.TextModality,
+	v1.ImageModality,
+	v1.AudioModality,
+	v1.VideoModality,
+	v1.EmbeddingModality,
+	v1.OtherModality,
+}
+
+var (
+	// fields maps the JSON paths of the queryable fields of v1.Model to their definition.
+	fields = make(map[string]*field)
+
+	// shortcuts maps the last element of the field paths to their field, when it is unique.
+	shortcuts = make(map[string]*field)
+)
+
+func init() {
+	collectFields(reflect.TypeOf(v1.Model{}), "", nil)
+
+	ambiguous := make(map[string]bool)
+	for path, f := range fields {
+		name := path[strings.LastIndex(path, ".")+1:]
+		if _, ok := shortcuts[name]; ok {
+			ambiguous[name] = true
+		}
+		shortcuts[name] = f
+	}
+	for name := range ambiguous {
+		delete(shortcuts, name)
+	}
+}
+
+// collectFields walks the struct type t and registers its leaves by their JSON path.
+func collectFields(t reflect.Type, prefix string, index []int) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "" || name == "-" || !sf.IsExported() {
+			continue
+		}
+		path := prefix + name
+		idx := append(append([]int(nil), index...), i)
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && ft != timeType {
+			collectFields(ft, path+".", idx)
+			continue
+		}
+		if k, ok := kindOf(path, ft); ok {
+			fields[path] = &field{path: path, index: idx, kind: k}
+		}
+	}
+}
+
+func kindOf(path string, t reflect.Type) (kind, bool) {
+	switch {
+	case path == paramSizePath:
+		return kindParamSize, true
+	case t == timeType:
+		return kindTime, true
+	case t.Kind() == reflect.String:
+		return kindString, true
+	case t.Kind() == reflect.Bool:
+		return kindBool, true
+	case t.Kind() == reflect.Slice && t.Elem() == modalityType:
+		return kindModalities, true
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String:
+		return kindList, true
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Float64:
+		return kindNumber, true
+	}
+	return 0, false
+}
+
+// lookupField resolves a field by its JSON path, or by its last path element when unambiguous.
+func lookupField(name string) (*field, error) {
+	if f, ok := fields[name]; ok {
+		return f, nil
+	}
+	if f, ok := shortcuts[name]; ok {
+		return f, nil
+	}
+	return nil, fmt.Errorf("unknown field %q", name)
+}
+
+// value returns the value of the field in m, or an invalid value if it is unset.
+func (f *field) value(m *v1.Model) reflect.Value {
+	v := reflect.ValueOf(m).Elem()
+	for _, i := range f.index {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
@@ -0,0 +1,239 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenType int
+
+const (
+	tokEOF tokenType = iota
+	tokIdent
+	tokString
+	tokLiteral
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	typ tokenType
+	val string
+	pos int
+}
+
+func (t token) String() string {
+	switch t.typ {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return strconv.Quote(t.val)
+	}
+	return fmt.Sprintf("%q", t.val)
+}
+
+// lex splits the expression into tokens.
+func lex(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case unicode.IsSpace(c):
+			i += size
+		case c == '(':
+			tokens = append(tokens, token{typ: tokLParen, val: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{typ: tokRParen, val: ")", pos: i})
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			val, err := strconv.Unquote(s[i : j+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at offset %d: %w", i, err)
+			}
+			tokens = append(tokens, token{typ: tokString, val: val, pos: i})
+			i = j + 1
+		case strings.ContainsRune("=!<>", c):
+			j := i + 1
+			if j < len(s) && s[j] == '=' {
+				j++
+			}
+			op := s[i:j]
+			if op == "=" || op == "!" {
+				return nil, fmt.Errorf("invalid operator %q at offset %d", op, i)
+			}
+			tokens = append(tokens, token{typ: tokOp, val: op, pos: i})
+			i = j
+		case c < utf8.RuneSelf && (unicode.IsLetter(c) || c == '_'):
+			j := i
+			for j < len(s) && isIdentByte(s[j]) {
+				j++
+			}
+			tokens = append(tokens, token{typ: tokIdent, val: s[i:j], pos: i})
+			i = j
+		case c >= '0' && c <= '9':
+			// numbers, param sizes and dates
+			j := i
+			for j < len(s) && (isIdentByte(s[j]) || strings.IndexByte(":+-", s[j]) >= 0) {
+				j++
+			}
+			tokens = append(tokens, token{typ: tokLiteral, val: s[i:j], pos: i})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q at offset %d", c, i)
+		}
+	}
+	return append(tokens, token{typ: tokEOF, pos: len(s)}), nil
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// parser is a recursive descent parser of the grammar:
+//
+//	expr       = and { "or" and }
+//	and        = unary { "and" unary }
+//	unary      = "not" unary | "(" expr ")" | comparison
+//	comparison = field [ operator value ]
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.typ != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// keyword reports whether the next token is the keyword kw, consuming it if so.
+func (p *parser) keyword(kw string) bool {
+	if t := p.peek(); t.typ == tokIdent && strings.EqualFold(t.val, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseExpr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.keyword("not") {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{x}, nil
+	}
+
+	t := p.next()
+	switch t.typ {
+	case tokLParen:
+		x, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.typ != tokRParen {
+			return nil, fmt.Errorf("expected \")\" at offset %d, got %s", t.pos, t)
+		}
+		return x, nil
+	case tokIdent:
+		return p.parseComparison(t)
+	}
+	return nil, fmt.Errorf("expected a field at offset %d, got %s", t.pos, t)
+}
+
+func (p *parser) parseComparison(name token) (node, error) {
+	f, err := lookupField(name.val)
+	if err != nil {
+		return nil, fmt.Errorf("offset %d: %w", name.pos, err)
+	}
+
+	var op string
+	switch t := p.peek(); {
+	case t.typ == tokOp:
+		op = t.val
+	case t.typ == tokIdent && (strings.EqualFold(t.val, opContains) || strings.EqualFold(t.val, opMatches)):
+		op = strings.ToLower(t.val)
+	case f.kind == kindBool:
+		// a boolean field alone tests for true
+		return newComparison(f, "==", token{typ: tokIdent, val: "true", pos: name.pos})
+	default:
+		return nil, fmt.Errorf("expected an operator after %s at offset %d, got %s", f.path, t.pos, t)
+	}
+	p.next()
+
+	value := p.next()
+	if value.typ != tokString && value.typ != tokLiteral && value.typ != tokIdent {
+		return nil, fmt.Errorf("expected a value after %s at offset %d, got %s", op, value.pos, value)
+	}
+	cmp, err := newComparison(f, op, value)
+	if err != nil {
+		return nil, fmt.Errorf("offset %d: %w", name.pos, err)
+	}
+	return cmp, nil
+}
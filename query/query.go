@@ -0,0 +1,257 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package query implements a filter expression language over model configs, such as
+//
+//	config.capabilities.inputTypes contains "image" and paramSize <= 8b and licenses matches "Apache-2.0"
+//
+// Fields are named by their JSON path in v1.Model, or by their last path element when it is unique.
+// Comparisons are typed by the field: config.paramSize compares parameter counts, so 8b and "8000m"
+// are equal, dates compare as times (2024-05-21 or RFC 3339), modality lists only accept known
+// modalities, and boolean fields may be used alone. `contains` tests list membership or substrings,
+// `matches` tests a regular expression against a string or any list element. Expressions combine
+// with `and`, `or`, `not` and parentheses. An unset field satisfies no comparison but `!=`.
+package query
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+const (
+	opContains = "contains"
+	opMatches  = "matches"
+)
+
+// Query is a compiled filter expression.
+type Query struct {
+	expr string
+	root node
+}
+
+// Parse compiles a filter expression.
+func Parse(expr string) (*Query, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	if t := p.peek(); t.typ != tokEOF {
+		return nil, fmt.Errorf("invalid query: unexpected %s at offset %d", t, t.pos)
+	}
+	return &Query{expr: expr, root: root}, nil
+}
+
+// MustParse is like Parse but panics if the expression is invalid.
+func MustParse(expr string) *Query {
+	q, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// String returns the source expression of the query.
+func (q *Query) String() string {
+	return q.expr
+}
+
+// Match reports whether the model satisfies the query.
+func (q *Query) Match(m *v1.Model) bool {
+	return q.root.eval(m)
+}
+
+// Filter returns the models satisfying the query, in order.
+func (q *Query) Filter(models []v1.Model) []v1.Model {
+	var matched []v1.Model
+	for i := range models {
+		if q.Match(&models[i]) {
+			matched = append(matched, models[i])
+		}
+	}
+	return matched
+}
+
+type node interface {
+	eval(m *v1.Model) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) eval(m *v1.Model) bool { return n.left.eval(m) && n.right.eval(m) }
+
+type orNode struct{ left, right node }
+
+func (n orNode) eval(m *v1.Model) bool { return n.left.eval(m) || n.right.eval(m) }
+
+type notNode struct{ x node }
+
+func (n notNode) eval(m *v1.Model) bool { return !n.x.eval(m) }
+
+// comparison compares a field to a value converted to the type of the field.
+type comparison struct {
+	field *field
+	op    string
+
+	text   string
+	re     *regexp.Regexp
+	size   uint64
+	time   time.Time
+	bool   bool
+	number float64
+}
+
+// operators lists the operators accepted by each kind of field.
+var operators = map[kind][]string{
+	kindString:     {"==", "!=", opContains, opMatches},
+	kindList:       {opContains, opMatches},
+	kindModalities: {opContains},
+	kindParamSize:  {"==", "!=", "<", "<=", ">", ">="},
+	kindTime:       {"==", "!=", "<", "<=", ">", ">="},
+	kindBool:       {"==", "!="},
+	kindNumber:     {"==", "!=", "<", "<=", ">", ">="},
+}
+
+func newComparison(f *field, op string, value token) (*comparison, error) {
+	if !slices.Contains(operators[f.kind], op) {
+		return nil, fmt.Errorf("operator %s not supported by %s field %s", op, f.kind, f.path)
+	}
+	if value.typ == tokIdent && f.kind != kindBool {
+		return nil, fmt.Errorf("expected a value for %s, got %s: strings must be quoted", f.path, value)
+	}
+
+	c := &comparison{field: f, op: op, text: value.val}
+	var err error
+	switch {
+	case op == opMatches:
+		if c.re, err = regexp.Compile(value.val); err != nil {
+			return nil, fmt.Errorf("invalid regular expression for %s: %w", f.path, err)
+		}
+	case f.kind == kindModalities:
+		if !slices.Contains(modalities, v1.Modality(value.val)) {
+			return nil, fmt.Errorf("unknown modality %q for %s, expected one of %v", value.val, f.path, modalities)
+		}
+	case f.kind == kindParamSize:
+		if c.size, err = v1.ParseParamSize(value.val); err != nil {
+			return nil, fmt.Errorf("invalid param size for %s: %w", f.path, err)
+		}
+	case f.kind == kindTime:
+		if c.time, err = parseTime(value.val); err != nil {
+			return nil, fmt.Errorf("invalid date for %s: %w", f.path, err)
+		}
+	case f.kind == kindBool:
+		if c.bool, err = strconv.ParseBool(strings.ToLower(value.val)); err != nil || value.typ != tokIdent {
+			return nil, fmt.Errorf("expected true or false for %s, got %s", f.path, value)
+		}
+	case f.kind == kindNumber:
+		if c.number, err = strconv.ParseFloat(value.val, 64); err != nil {
+			return nil, fmt.Errorf("invalid number for %s: %w", f.path, err)
+		}
+	}
+	return c, nil
+}
+
+// parseTime accepts RFC 3339 times and plain dates in UTC.
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, s)
+}
+
+func (c *comparison) eval(m *v1.Model) bool {
+	v := c.field.value(m)
+	if !v.IsValid() || v.IsZero() && c.field.kind != kindBool && c.field.kind != kindNumber {
+		return c.op == "!="
+	}
+
+	switch c.field.kind {
+	case kindString:
+		s := v.String()
+		switch c.op {
+		case opContains:
+			return strings.Contains(s, c.text)
+		case opMatches:
+			return c.re.MatchString(s)
+		}
+		return compare(c.op, strings.Compare(s, c.text))
+	case kindList, kindModalities:
+		for i := 0; i < v.Len(); i++ {
+			s := v.Index(i).String()
+			if c.op == opMatches && c.re.MatchString(s) || c.op == opContains && s == c.text {
+				return true
+			}
+		}
+		return false
+	case kindParamSize:
+		size, err := v1.ParseParamSize(v.String())
+		if err != nil {
+			return c.op == "!="
+		}
+		return compare(c.op, cmp.Compare(size, c.size))
+	case kindTime:
+		return compare(c.op, v.Interface().(time.Time).Compare(c.time))
+	case kindBool:
+		if v.Bool() == c.bool {
+			return c.op == "=="
+		}
+		return c.op == "!="
+	case kindNumber:
+		return compare(c.op, cmp.Compare(toFloat(v), c.number))
+	}
+	return false
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+// compare applies a comparison operator to the result of a three-way comparison.
+func compare(op string, result int) bool {
+	switch op {
+	case "==":
+		return result == 0
+	case "!=":
+		return result != 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	}
+	return false
+}
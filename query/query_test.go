@@ -0,0 +1,130 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/modelpack/model-spec/query"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+func models() []v1.Model {
+	yes := true
+	created := time.Date(2024, 7, 23, 0, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	return []v1.Model{
+		{
+			Descriptor: v1.ModelDescriptor{Name: "llava-7b", CreatedAt: &created, Licenses: []string{"Apache-2.0"}},
+			Config: v1.ModelConfig{
+				ParamSize: "7b",
+				Capabilities: &v1.ModelCapabilities{
+					InputTypes:      []v1.Modality{v1.TextModality, v1.ImageModality},
+					KnowledgeCutoff: &cutoff,
+					Reasoning:       &yes,
+				},
+			},
+		},
+		{
+			Descriptor: v1.ModelDescriptor{Name: "llama3-8b", Licenses: []string{"llama3"}},
+			Config: v1.ModelConfig{
+				ParamSize:    "8.0b",
+				Capabilities: &v1.ModelCapabilities{InputTypes: []v1.Modality{v1.TextModality}},
+			},
+		},
+		{
+			Descriptor: v1.ModelDescriptor{Name: "qwen2-vl-72b", Licenses: []string{"Apache-2.0"}},
+			Config: v1.ModelConfig{
+				ParamSize:    "72b",
+				Capabilities: &v1.ModelCapabilities{InputTypes: []v1.Modality{v1.TextModality, v1.ImageModality}},
+			},
+		},
+		{
+			Descriptor: v1.ModelDescriptor{Name: "unknown"},
+		},
+	}
+}
+
+func TestMatch(t *testing.T) {
+	for i, tt := range []struct {
+		query    string
+		expected []string
+	}{
+		{
+			query:    `config.capabilities.inputTypes contains "image" and paramSize <= 8b and licenses matches "Apache-2.0"`,
+			expected: []string{"llava-7b"},
+		},
+		{query: `paramSize == "8000m"`, expected: []string{"llama3-8b"}},
+		{query: `paramSize > 7.5B`, expected: []string{"llama3-8b", "qwen2-vl-72b"}},
+		{query: `name contains "b" and not (name matches "^llama" or paramSize >= 70b)`, expected: []string{"llava-7b"}},
+		{query: `inputTypes contains "image" OR licenses contains "llama3"`, expected: []string{"llava-7b", "llama3-8b", "qwen2-vl-72b"}},
+		{query: `knowledgeCutoff > 2023-06-01`, expected: []string{"llava-7b"}},
+		{query: `createdAt < 2024-07-23T00:00:01Z and createdAt >= 2024-07-23`, expected: []string{"llava-7b"}},
+		{query: `reasoning`, expected: []string{"llava-7b"}},
+		{query: `reasoning != true`, expected: []string{"llama3-8b", "qwen2-vl-72b", "unknown"}},
+		{query: `descriptor.name == "unknown" or paramSize != 72b`, expected: []string{"llava-7b", "llama3-8b", "unknown"}},
+	} {
+		q, err := query.Parse(tt.query)
+		if err != nil {
+			t.Errorf("test %d: %v", i, err)
+			continue
+		}
+		var names []string
+		for _, m := range q.Filter(models()) {
+			names = append(names, m.Descriptor.Name)
+		}
+		if len(names) != len(tt.expected) {
+			t.Errorf("test %d: expected %v, got %v", i, tt.expected, names)
+			continue
+		}
+		for j := range names {
+			if names[j] != tt.expected[j] {
+				t.Errorf("test %d: expected %v, got %v", i, tt.expected, names)
+				break
+			}
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for i, expr := range []string{
+		``,
+		`name`,
+		`name ==`,
+		`name == llama`,
+		`unknown == "x"`,
+		`Name == "x"`,
+		`paramSize <= 8g`,
+		`paramSize contains "8b"`,
+		`inputTypes contains "smell"`,
+		`inputTypes == "text"`,
+		`licenses matches "("`,
+		`knowledgeCutoff > "yesterday"`,
+		`reasoning == "true"`,
+		`(name == "x"`,
+		`name == "x" name == "y"`,
+		`name = "x"`,
+		`name == "x`,
+		`name == "x" and é`,
+		`paramSize <= ٨b`,
+	} {
+		if _, err := query.Parse(expr); err == nil {
+			t.Errorf("test %d: expected %q to fail", i, expr)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package safepath
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Validate checks that p is a relative, slash separated path which stays within the directory it is extracted to.
+// It rejects empty and absolute paths, `..` traversal, NUL bytes, backslashes and Windows drive letters.
+func Validate(p string) error {
+	if p == "" {
+		return errors.New("path is empty")
+	}
+	if strings.ContainsRune(p, 0) {
+		return fmt.Errorf("path %q contains a NUL byte", p)
+	}
+	if strings.ContainsRune(p, '\\') {
+		return fmt.Errorf("path %q contains a backslash", p)
+	}
+	if len(p) >= 2 && p[1] == ':' && isLetter(p[0]) {
+		return fmt.Errorf("path %q starts with a drive letter", p)
+	}
+	if path.IsAbs(p) {
+		return fmt.Errorf("path %q is absolute", p)
+	}
+	for _, elem := range strings.Split(p, "/") {
+		if elem == ".." {
+			return fmt.Errorf("path %q contains a parent directory reference", p)
+		}
+	}
+	return nil
+}
+
+// ValidateManifest checks the `org.cncf.model.filepath` annotation of every layer of the manifest,
+// and that no two layers are extracted to the same path.
+func ValidateManifest(manifest ocispec.Manifest) error {
+	var errs []error
+	seen := make(map[string]int)
+	for i, layer := range manifest.Layers {
+		p, ok := layer.Annotations[v1.AnnotationFilepath]
+		if !ok {
+			continue
+		}
+		if err := Validate(p); err != nil {
+			errs = append(errs, fmt.Errorf("layer %d: %w", i, err))
+			continue
+		}
+		clean := path.Clean(p)
+		if j, ok := seen[clean]; ok {
+			errs = append(errs, fmt.Errorf("layer %d: path %q is also used by layer %d", i, p, j))
+			continue
+		}
+		seen[clean] = i
+	}
+	return errors.Join(errs...)
+}
+
+// Checker validates the entries of tar streams during extraction.
+// A single Checker may be shared across the layers of a manifest to reject
+// files that are written more than once.
+type Checker struct {
+	seen map[string]string
+}
+
+// NewChecker returns a Checker which has not seen any entry.
+func NewChecker() *Checker {
+	return &Checker{seen: make(map[string]string)}
+}
+
+// Check validates the name and link target of the tar entry hdr.
+// Directories may appear several times, any other entry only once.
+// Link targets are resolved lexically, extractors must additionally refuse
+// to write through symlinks created by earlier entries.
+func (c *Checker) Check(hdr *tar.Header) error {
+	if err := Validate(hdr.Name); err != nil {
+		return fmt.Errorf("tar entry: %w", err)
+	}
+	clean := path.Clean(hdr.Name)
+
+	switch hdr.Typeflag {
+	case tar.TypeSymlink:
+		// symlink targets are relative to the directory of the link
+		if path.IsAbs(hdr.Linkname) {
+			return fmt.Errorf("symlink %q target %q is absolute", hdr.Name, hdr.Linkname)
+		}
+		if err := Validate(path.Join(path.Dir(clean), hdr.Linkname)); err != nil {
+			return fmt.Errorf("symlink %q target: %w", hdr.Name, err)
+		}
+	case tar.TypeLink:
+		if err := Validate(hdr.Linkname); err != nil {
+			return fmt.Errorf("hard link %q target: %w", hdr.Name, err)
+		}
+	}
+
+	return c.add(clean, hdr.Typeflag == tar.TypeDir)
+}
+
+// CheckPath records p as a file written outside of a tar stream, such as a raw layer.
+func (c *Checker) CheckPath(p string) error {
+	if err := Validate(p); err != nil {
+		return err
+	}
+	return c.add(path.Clean(p), false)
+}
+
+func (c *Checker) add(clean string, dir bool) error {
+	kind := "file"
+	if dir {
+		kind = "dir"
+	}
+	if prev, ok := c.seen[clean]; ok && (!dir || prev != "dir") {
+		return fmt.Errorf("duplicate path %q", clean)
+	}
+	c.seen[clean] = kind
+	return nil
+}
+
+func isLetter(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
@@ -0,0 +1,109 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package safepath_test
+
+import (
+	"archive/tar"
+	"testing"
+
+	"github.com/modelpack/model-spec/safepath"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tt := range []struct {
+		path string
+		fail bool
+	}{
+		{path: "model.safetensors"},
+		{path: "weights/model-00001-of-00002.safetensors"},
+		{path: "./config.json"},
+		{path: "dir/../config.json", fail: true},
+		{path: "../../etc/passwd", fail: true},
+		{path: "..", fail: true},
+		{path: "/etc/passwd", fail: true},
+		{path: "", fail: true},
+		{path: "model\x00.bin", fail: true},
+		{path: `C:\Windows\system32`, fail: true},
+		{path: "C:/Windows", fail: true},
+		{path: `weights\model.bin`, fail: true},
+	} {
+		err := safepath.Validate(tt.path)
+		if got := err != nil; got != tt.fail {
+			t.Errorf("%q: expected failure %t but got %t, err %v", tt.path, tt.fail, got, err)
+		}
+	}
+}
+
+func TestValidateManifest(t *testing.T) {
+	layer := func(p string) ocispec.Descriptor {
+		return ocispec.Descriptor{
+			MediaType:   v1.MediaTypeModelWeightRaw,
+			Annotations: map[string]string{v1.AnnotationFilepath: p},
+		}
+	}
+
+	for i, tt := range []struct {
+		layers []ocispec.Descriptor
+		fail   bool
+	}{
+		{layers: []ocispec.Descriptor{layer("a.safetensors"), layer("b.safetensors"), {MediaType: v1.MediaTypeModelDoc}}},
+		{layers: []ocispec.Descriptor{layer("a.safetensors"), layer("../b.safetensors")}, fail: true},
+		{layers: []ocispec.Descriptor{layer("a.safetensors"), layer("./a.safetensors")}, fail: true},
+	} {
+		err := safepath.ValidateManifest(ocispec.Manifest{Layers: tt.layers})
+		if got := err != nil; got != tt.fail {
+			t.Errorf("test %d: expected failure %t but got %t, err %v", i, tt.fail, got, err)
+		}
+	}
+}
+
+func TestChecker(t *testing.T) {
+	for i, tt := range []struct {
+		headers []tar.Header
+		fail    bool
+	}{
+		{headers: []tar.Header{
+			{Typeflag: tar.TypeDir, Name: "weights/"},
+			{Typeflag: tar.TypeReg, Name: "weights/model.bin"},
+			{Typeflag: tar.TypeDir, Name: "weights/"},
+			{Typeflag: tar.TypeSymlink, Name: "weights/latest", Linkname: "model.bin"},
+			{Typeflag: tar.TypeSymlink, Name: "weights/sub/link", Linkname: "../model.bin"},
+			{Typeflag: tar.TypeLink, Name: "model.bin", Linkname: "weights/model.bin"},
+		}},
+		{headers: []tar.Header{{Typeflag: tar.TypeReg, Name: "/etc/passwd"}}, fail: true},
+		{headers: []tar.Header{{Typeflag: tar.TypeSymlink, Name: "link", Linkname: "/etc"}}, fail: true},
+		{headers: []tar.Header{{Typeflag: tar.TypeSymlink, Name: "dir/link", Linkname: "../../etc"}}, fail: true},
+		{headers: []tar.Header{{Typeflag: tar.TypeLink, Name: "link", Linkname: "../etc/passwd"}}, fail: true},
+		{headers: []tar.Header{
+			{Typeflag: tar.TypeReg, Name: "model.bin"},
+			{Typeflag: tar.TypeReg, Name: "./model.bin"},
+		}, fail: true},
+	} {
+		c := safepath.NewChecker()
+		var err error
+		for _, hdr := range tt.headers {
+			if err = c.Check(&hdr); err != nil {
+				break
+			}
+		}
+		if got := err != nil; got != tt.fail {
+			t.Errorf("test %d: expected failure %t but got %t, err %v", i, tt.fail, got, err)
+		}
+	}
+}
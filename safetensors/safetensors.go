@@ -0,0 +1,342 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package safetensors reads the headers of safetensors files, without reading the tensor data,
+// to derive the parameter count and precision of a model.
+package safetensors
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/modelpack/model-spec/safepath"
+)
+
+const (
+	// IndexFile is the name of the index of sharded safetensors checkpoints.
+	IndexFile = "model.safetensors.index.json"
+
+	// MaxHeaderSize is the largest accepted header, as in the reference implementation.
+	MaxHeaderSize = 100 << 20
+
+	metadataKey = "__metadata__"
+)
+
+// dtypeBits maps the safetensors dtypes to their width in bits.
+var dtypeBits = map[string]uint64{
+	"BOOL":    8,
+	"U8":      8,
+	"I8":      8,
+	"F8_E4M3": 8,
+	"F8_E5M2": 8,
+	"F8_E8M0": 8,
+	"I16":     16,
+	"U16":     16,
+	"F16":     16,
+	"BF16":    16,
+	"I32":     32,
+	"U32":     32,
+	"F32":     32,
+	"I64":     64,
+	"U64":     64,
+	"F64":     64,
+	"C64":     64,
+	"F4":      4,
+	"F6_E2M3": 6,
+	"F6_E3M2": 6,
+}
+
+// Tensor is a tensor described by a safetensors header.
+type Tensor struct {
+	Name  string
+	DType string   `json:"dtype"`
+	Shape []uint64 `json:"shape"`
+
+	// DataOffsets are the start and end of the tensor data, relative to the end of the header.
+	DataOffsets [2]uint64 `json:"data_offsets"`
+}
+
+// Params returns the number of elements of the tensor, which is 1 for scalars.
+func (t Tensor) Params() (uint64, error) {
+	n := uint64(1)
+	for _, d := range t.Shape {
+		hi, lo := bits.Mul64(n, d)
+		if hi != 0 {
+			return 0, fmt.Errorf("tensor %q: shape %v overflows", t.Name, t.Shape)
+		}
+		n = lo
+	}
+	return n, nil
+}
+
+// Header is the header of a safetensors file.
+type Header struct {
+	// Tensors are sorted by name.
+	Tensors []Tensor
+
+	// Metadata is the free-form __metadata__ of the header.
+	Metadata map[string]string
+
+	// Size is the size of the header, including its 8-byte length prefix.
+	Size uint64
+}
+
+// DataSize returns the size of the tensor data following the header.
+func (h *Header) DataSize() uint64 {
+	var size uint64
+	for _, t := range h.Tensors {
+		size = max(size, t.DataOffsets[1])
+	}
+	return size
+}
+
+// ReadHeader reads the header at the start of a safetensors file, leaving r after the header.
+func ReadHeader(r io.Reader) (*Header, error) {
+	var length uint64
+	if err := binary.Read(r, binary.LittleEndian, &length); err != nil {
+		return nil, fmt.Errorf("failed to read header length: %w", err)
+	}
+	if length > MaxHeaderSize {
+		return nil, fmt.Errorf("header of %d bytes exceeds the maximum of %d", length, MaxHeaderSize)
+	}
+	raw := make([]byte, length)
+	if _, err := io.ReadFull(r, raw); err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("invalid header: %w", err)
+	}
+	h := &Header{Size: 8 + length}
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	// sorting the names keeps the tensors and errors in a stable order
+	sort.Strings(names)
+	var errs []error
+	for _, name := range names {
+		entry := entries[name]
+		if name == metadataKey {
+			if err := json.Unmarshal(entry, &h.Metadata); err != nil {
+				errs = append(errs, fmt.Errorf("invalid %s: %w", metadataKey, err))
+			}
+			continue
+		}
+		t := Tensor{Name: name}
+		if err := json.Unmarshal(entry, &t); err != nil {
+			errs = append(errs, fmt.Errorf("invalid tensor %q: %w", name, err))
+			continue
+		}
+		if err := t.validate(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		h.Tensors = append(h.Tensors, t)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid header: %w", err)
+	}
+	return h, nil
+}
+
+// validate checks that the data offsets of the tensor match its dtype and shape.
+func (t Tensor) validate() error {
+	if t.DType == "" {
+		return fmt.Errorf("tensor %q has no dtype", t.Name)
+	}
+	start, end := t.DataOffsets[0], t.DataOffsets[1]
+	if start > end {
+		return fmt.Errorf("tensor %q: data offsets %v are reversed", t.Name, t.DataOffsets)
+	}
+	n, err := t.Params()
+	if err != nil {
+		return err
+	}
+	width, ok := dtypeBits[t.DType]
+	if !ok {
+		// unknown dtypes are counted without checking their size
+		return nil
+	}
+	hi, lo := bits.Mul64(n, width)
+	if hi != 0 || (lo+7)/8 != end-start {
+		return fmt.Errorf("tensor %q: %d bytes of data do not match %s%v", t.Name, end-start, t.DType, t.Shape)
+	}
+	return nil
+}
+
+// ReadFile reads the header of a safetensors file and checks that the file holds the tensor data.
+func ReadFile(path string) (*Header, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h, err := ReadHeader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if size := h.Size + h.DataSize(); uint64(info.Size()) < size {
+		return nil, fmt.Errorf("%s: file of %d bytes is truncated, expected %d", path, info.Size(), size)
+	}
+	return h, nil
+}
+
+// Index is the index of a sharded safetensors checkpoint.
+type Index struct {
+	// WeightMap maps the tensor names to the file holding them.
+	WeightMap map[string]string `json:"weight_map"`
+}
+
+// Files returns the sorted files of the index.
+func (i *Index) Files() []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, file := range i.WeightMap {
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// ReadIndex reads a model.safetensors.index.json.
+func ReadIndex(r io.Reader) (*Index, error) {
+	var i Index
+	if err := json.NewDecoder(r).Decode(&i); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", IndexFile, err)
+	}
+	if len(i.WeightMap) == 0 {
+		return nil, fmt.Errorf("invalid %s: weight_map is empty", IndexFile)
+	}
+	return &i, nil
+}
+
+// ReadDir reads the headers of the safetensors checkpoint of a directory. When the directory has
+// a model.safetensors.index.json, the files it lists are read and must hold the tensors it maps to
+// them. Otherwise the directory must hold a single .safetensors file, or only the complete shards
+// of one checkpoint, such as model-00001-of-00002.safetensors, since other files alongside them,
+// such as consolidated or adapter weights, would be counted twice.
+func ReadDir(dir string) ([]*Header, error) {
+	f, err := os.Open(filepath.Join(dir, IndexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		files, err := filepath.Glob(filepath.Join(dir, "*.safetensors"))
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no safetensors files in %s", dir)
+		}
+		if len(files) > 1 && !isShards(files) {
+			return nil, fmt.Errorf("%d safetensors files in %s are not the shards of one checkpoint and there is no %s", len(files), dir, IndexFile)
+		}
+		return readFiles(files)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	index, err := ReadIndex(f)
+	if err != nil {
+		return nil, err
+	}
+	files := index.Files()
+	for i, file := range files {
+		if err := safepath.Validate(file); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", IndexFile, err)
+		}
+		files[i] = filepath.Join(dir, filepath.FromSlash(file))
+	}
+	headers, err := readFiles(files)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]string)
+	for i, h := range headers {
+		for _, t := range h.Tensors {
+			found[t.Name] = files[i]
+		}
+	}
+	names := make([]string, 0, len(index.WeightMap))
+	for name := range index.WeightMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var errs []error
+	for _, name := range names {
+		file := index.WeightMap[name]
+		if found[name] != filepath.Join(dir, filepath.FromSlash(file)) {
+			errs = append(errs, fmt.Errorf("tensor %q is not in %s", name, file))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("inconsistent %s: %w", IndexFile, err)
+	}
+	return headers, nil
+}
+
+// shardRegexp matches the names of the shards of a checkpoint, such as model-00001-of-00002.safetensors.
+var shardRegexp = regexp.MustCompile(`^(.+)-([0-9]+)-of-([0-9]+)\.safetensors$`)
+
+// isShards reports whether the files are all the shards of the same checkpoint.
+func isShards(files []string) bool {
+	var prefix, total string
+	for i, file := range files {
+		m := shardRegexp.FindStringSubmatch(filepath.Base(file))
+		if m == nil {
+			return false
+		}
+		if i == 0 {
+			prefix, total = m[1], m[3]
+		}
+		if m[1] != prefix || m[3] != total {
+			return false
+		}
+	}
+	n, err := strconv.Atoi(total)
+	return err == nil && n == len(files)
+}
+
+func readFiles(files []string) ([]*Header, error) {
+	headers := make([]*Header, 0, len(files))
+	for _, file := range files {
+		h, err := ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, h)
+	}
+	return headers, nil
+}
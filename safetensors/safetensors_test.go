@@ -0,0 +1,193 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package safetensors_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelpack/model-spec/safetensors"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+// file builds a safetensors file from its JSON header, with zeroed tensor data.
+func file(header string, dataSize int) []byte {
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, uint64(len(header)))
+	b.WriteString(header)
+	b.Write(make([]byte, dataSize))
+	return b.Bytes()
+}
+
+const shard1 = `{
+  "__metadata__": {"format": "pt"},
+  "model.embed_tokens.weight": {"dtype": "BF16", "shape": [1000, 64], "data_offsets": [0, 128000]},
+  "model.norm.weight": {"dtype": "F32", "shape": [64], "data_offsets": [128000, 128256]}
+}`
+
+const shard2 = `{
+  "lm_head.weight": {"dtype": "BF16", "shape": [1000, 64], "data_offsets": [0, 128000]},
+  "position_ids": {"dtype": "I64", "shape": [1, 16], "data_offsets": [128000, 128128]}
+}`
+
+const index = `{
+  "metadata": {"total_size": 256384},
+  "weight_map": {
+    "model.embed_tokens.weight": "model-00001-of-00002.safetensors",
+    "model.norm.weight": "model-00001-of-00002.safetensors",
+    "lm_head.weight": "model-00002-of-00002.safetensors",
+    "position_ids": "model-00002-of-00002.safetensors"
+  }
+}`
+
+func writeFiles(t *testing.T, files map[string][]byte) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestReadHeader(t *testing.T) {
+	for i, tt := range []struct {
+		header string
+		fail   bool
+	}{
+		{header: shard1},
+		{header: `{"scalar": {"dtype": "F32", "shape": [], "data_offsets": [0, 4]}}`},
+		{header: `{"packed": {"dtype": "F4", "shape": [3], "data_offsets": [0, 2]}}`},
+		// size mismatch
+		{header: `{"w": {"dtype": "F16", "shape": [2, 2], "data_offsets": [0, 4]}}`, fail: true},
+		// reversed offsets
+		{header: `{"w": {"dtype": "U8", "shape": [0], "data_offsets": [4, 0]}}`, fail: true},
+		// missing dtype
+		{header: `{"w": {"shape": [1], "data_offsets": [0, 1]}}`, fail: true},
+		// overflowing shape
+		{header: `{"w": {"dtype": "U8", "shape": [4294967296, 4294967296], "data_offsets": [0, 1]}}`, fail: true},
+		{header: `[]`, fail: true},
+	} {
+		h, err := safetensors.ReadHeader(bytes.NewReader(file(tt.header, 0)))
+		if (err != nil) != tt.fail {
+			t.Errorf("test %d: expected failure %t, got %v", i, tt.fail, err)
+			continue
+		}
+		if i == 0 && (len(h.Tensors) != 2 || h.Tensors[0].Name != "model.embed_tokens.weight" || h.Metadata["format"] != "pt") {
+			t.Errorf("test %d: unexpected header %+v", i, h)
+		}
+	}
+
+	// the errors of several tensors are listed by name
+	_, err := safetensors.ReadHeader(bytes.NewReader(file(`{"b": {"shape": [1]}, "a": {"shape": [1]}, "c": {"shape": [1]}}`, 0)))
+	if err == nil || err.Error() != "invalid header: tensor \"a\" has no dtype\ntensor \"b\" has no dtype\ntensor \"c\" has no dtype" {
+		t.Errorf("expected the errors sorted by tensor name, got %v", err)
+	}
+
+	// headers larger than the maximum are rejected before being read
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, uint64(safetensors.MaxHeaderSize+1))
+	if _, err := safetensors.ReadHeader(&b); err == nil {
+		t.Errorf("expected failure for a header exceeding the maximum size")
+	}
+}
+
+func TestReadDir(t *testing.T) {
+	dir := writeFiles(t, map[string][]byte{
+		safetensors.IndexFile:              []byte(index),
+		"model-00001-of-00002.safetensors": file(shard1, 128256),
+		"model-00002-of-00002.safetensors": file(shard2, 128128),
+	})
+	headers, err := safetensors.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := safetensors.Summarize(headers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Params != 128080 || s.DTypes["BF16"] != 128000 || s.DTypes["F32"] != 64 || s.DTypes["I64"] != 16 {
+		t.Errorf("unexpected summary %+v", s)
+	}
+	if s.ParamSize() != "128.1k" || s.Precision() != "bfloat16" {
+		t.Errorf("expected 128.1k parameters in bfloat16, got %s in %s", s.ParamSize(), s.Precision())
+	}
+
+	// a tensor mapped to the wrong shard
+	wrong := strings.Replace(index, `"lm_head.weight": "model-00002`, `"lm_head.weight": "model-00001`, 1)
+	if err := os.WriteFile(filepath.Join(dir, safetensors.IndexFile), []byte(wrong), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := safetensors.ReadDir(dir); err == nil || !strings.Contains(err.Error(), "lm_head.weight") {
+		t.Errorf("expected an inconsistent index, got %v", err)
+	}
+
+	// complete shards without index
+	dir = writeFiles(t, map[string][]byte{
+		"model-00001-of-00002.safetensors": file(shard1, 128256),
+		"model-00002-of-00002.safetensors": file(shard2, 128128),
+	})
+	if headers, err := safetensors.ReadDir(dir); err != nil || len(headers) != 2 {
+		t.Errorf("expected the 2 shards to be read, got %v", err)
+	}
+
+	// consolidated weights alongside the shards would be counted twice
+	if err := os.WriteFile(filepath.Join(dir, "consolidated.safetensors"), file(shard1, 128256), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := safetensors.ReadDir(dir); err == nil {
+		t.Errorf("expected failure for ambiguous safetensors files")
+	}
+
+	// a single truncated file
+	dir = writeFiles(t, map[string][]byte{"model.safetensors": file(shard1, 1000)})
+	if _, err := safetensors.ReadDir(dir); err == nil {
+		t.Errorf("expected failure for a truncated file")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	s := safetensors.Summary{Params: 8030261248, DTypes: map[string]uint64{"BF16": 8030261248}}
+	for i, tt := range []struct {
+		config v1.ModelConfig
+		fail   bool
+	}{
+		{config: v1.ModelConfig{ParamSize: "8b", Precision: "bf16"}},
+		{config: v1.ModelConfig{ParamSize: "8.0b", Precision: "bfloat16,float32"}},
+		{config: v1.ModelConfig{ParamSize: "8030m", Precision: "mixed"}},
+		{config: v1.ModelConfig{}},
+		{config: v1.ModelConfig{ParamSize: "7b", Quantization: "gptq"}},
+		{config: v1.ModelConfig{ParamSize: "7b"}, fail: true},
+		{config: v1.ModelConfig{ParamSize: "8.1b"}, fail: true},
+		{config: v1.ModelConfig{Precision: "float16"}, fail: true},
+		{config: v1.ModelConfig{ParamSize: "8"}, fail: true},
+	} {
+		if err := s.Check(tt.config); (err != nil) != tt.fail {
+			t.Errorf("test %d: expected failure %t, got %v", i, tt.fail, err)
+		}
+	}
+
+	c := v1.ModelConfig{Precision: "bf16"}
+	s.Fill(&c)
+	if c.ParamSize != "8b" || c.Precision != "bf16" {
+		t.Errorf("expected only paramSize to be filled, got %+v", c)
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package safetensors
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+// MinPrecisionShare is the share of the parameters a dtype must hold to be listed in the precision,
+// leaving out small buffers such as int64 position ids.
+const MinPrecisionShare = 0.01
+
+// dtypePrecisions maps the safetensors dtypes to the precisions of the model config.
+// Other dtypes are lowercased.
+var dtypePrecisions = map[string]string{
+	"BOOL":    "bool",
+	"U8":      "uint8",
+	"I8":      "int8",
+	"F8_E4M3": "float8_e4m3",
+	"F8_E5M2": "float8_e5m2",
+	"I16":     "int16",
+	"U16":     "uint16",
+	"F16":     "float16",
+	"BF16":    "bfloat16",
+	"I32":     "int32",
+	"U32":     "uint32",
+	"F32":     "float32",
+	"I64":     "int64",
+	"U64":     "uint64",
+	"F64":     "float64",
+	"C64":     "complex64",
+}
+
+// precisionAliases maps the common abbreviations of precisions to their name.
+var precisionAliases = map[string]string{
+	"bf16": "bfloat16",
+	"fp16": "float16",
+	"f16":  "float16",
+	"half": "float16",
+	"fp32": "float32",
+	"f32":  "float32",
+	"fp64": "float64",
+}
+
+// Summary is the parameter count and dtype histogram of a checkpoint.
+type Summary struct {
+	// Params is the total number of tensor elements.
+	Params uint64
+
+	// DTypes maps the safetensors dtypes to their number of elements.
+	DTypes map[string]uint64
+}
+
+// Summarize counts the tensor elements of the headers of a checkpoint.
+func Summarize(headers ...*Header) (Summary, error) {
+	s := Summary{DTypes: make(map[string]uint64)}
+	for _, h := range headers {
+		for _, t := range h.Tensors {
+			n, err := t.Params()
+			if err != nil {
+				return Summary{}, err
+			}
+			if s.Params+n < s.Params {
+				return Summary{}, errors.New("parameter count overflows")
+			}
+			s.Params += n
+			s.DTypes[t.DType] += n
+		}
+	}
+	return s, nil
+}
+
+// ParamSize formats the parameter count as a ModelConfig.ParamSize value.
+func (s Summary) ParamSize() string {
+	return v1.FormatParamSize(s.Params)
+}
+
+// Precision returns the precisions of the dtypes holding at least MinPrecisionShare of the parameters,
+// comma-separated by decreasing share, such as "bfloat16" or "bfloat16,float32".
+func (s Summary) Precision() string {
+	return strings.Join(s.precisions(), ",")
+}
+
+func (s Summary) precisions() []string {
+	var dtypes []string
+	for dtype, n := range s.DTypes {
+		if float64(n) >= MinPrecisionShare*float64(s.Params) {
+			dtypes = append(dtypes, dtype)
+		}
+	}
+	sort.Slice(dtypes, func(i, j int) bool {
+		a, b := dtypes[i], dtypes[j]
+		return s.DTypes[a] > s.DTypes[b] || s.DTypes[a] == s.DTypes[b] && a < b
+	})
+
+	precisions := make([]string, len(dtypes))
+	for i, dtype := range dtypes {
+		if p, ok := dtypePrecisions[dtype]; ok {
+			precisions[i] = p
+		} else {
+			precisions[i] = strings.ToLower(dtype)
+		}
+	}
+	return precisions
+}
+
+// quantized reports whether the config describes a quantized model, whose checkpoints may pack
+// several weights in one tensor element and so do not give the parameter count and precision.
+func quantized(c v1.ModelConfig) bool {
+	return c.Quantization != "" || c.QuantizationDetails != nil
+}
+
+// Fill sets the ParamSize and Precision of the config when they are empty and the model is not quantized.
+func (s Summary) Fill(c *v1.ModelConfig) {
+	if quantized(*c) || s.Params == 0 {
+		return
+	}
+	if c.ParamSize == "" {
+		c.ParamSize = s.ParamSize()
+	}
+	if c.Precision == "" {
+		c.Precision = s.Precision()
+	}
+}
+
+// Check cross-checks the ParamSize and Precision of the config with the checkpoint, unless the model
+// is quantized. The parameter size must match the parameter count rounded to its number of digits,
+// so "8b" matches 8030261248 parameters, and the precision must list the precisions of the checkpoint,
+// unless it is "mixed".
+func (s Summary) Check(c v1.ModelConfig) error {
+	if quantized(c) || s.Params == 0 {
+		return nil
+	}
+
+	var errs []error
+	if c.ParamSize != "" {
+		if err := s.checkParamSize(c.ParamSize); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if c.Precision != "" && !strings.EqualFold(c.Precision, "mixed") {
+		declared := make(map[string]bool)
+		for _, p := range strings.Split(c.Precision, ",") {
+			p = strings.ToLower(strings.TrimSpace(p))
+			if alias, ok := precisionAliases[p]; ok {
+				p = alias
+			}
+			declared[p] = true
+		}
+		for _, p := range s.precisions() {
+			if !declared[p] {
+				errs = append(errs, fmt.Errorf("precision %q does not list %s, used by the checkpoint", c.Precision, p))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s Summary) checkParamSize(paramSize string) error {
+	ok, err := v1.ParamSizeMatches(paramSize, s.Params)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("paramSize %s does not match the %d parameters of the checkpoint (%s)", paramSize, s.Params, s.ParamSize())
+	}
+	return nil
+}
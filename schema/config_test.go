@@ -669,6 +669,816 @@ func TestConfig(t *testing.T) {
 `,
 			fail: false,
 		},
+		// valid: runtime parameters
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct"
+  },
+  "config": {
+    "paramSize": "8b",
+    "runtime": {
+      "maxContextLength": 8192,
+      "maxOutputTokens": 4096,
+      "sampling": {"temperature": 0, "topP": 1, "topK": 40, "minP": 0.05, "repetitionPenalty": 1.1},
+      "stopSequences": ["</s>"],
+      "engines": [{"name": "vllm", "minVersion": "0.6.0", "flags": ["--trust-remote-code"]}, {"name": "sglang"}]
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: false,
+		},
+		// expected failure: maxOutputTokens exceeds maxContextLength
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct"
+  },
+  "config": {
+    "paramSize": "8b",
+    "runtime": {
+      "maxContextLength": 4096,
+      "maxOutputTokens": 8192
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: maxContextLength is zero
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct"
+  },
+  "config": {
+    "paramSize": "8b",
+    "runtime": {
+      "maxContextLength": 0
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: topP is out of range
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct"
+  },
+  "config": {
+    "paramSize": "8b",
+    "runtime": {
+      "sampling": {"topP": 1.5}
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: temperature is negative
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct"
+  },
+  "config": {
+    "paramSize": "8b",
+    "runtime": {
+      "sampling": {"temperature": -0.1}
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: engine name is missing
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct"
+  },
+  "config": {
+    "paramSize": "8b",
+    "runtime": {
+      "engines": [{"minVersion": "0.6.0"}]
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: engine is listed twice
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct"
+  },
+  "config": {
+    "paramSize": "8b",
+    "runtime": {
+      "engines": [{"name": "vllm"}, {"name": "vllm", "minVersion": "0.6.0"}]
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: stop sequence is empty
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct"
+  },
+  "config": {
+    "paramSize": "8b",
+    "runtime": {
+      "stopSequences": [""]
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// valid: hardware requirements
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct"
+  },
+  "config": {
+    "paramSize": "8b",
+    "hardware": {
+      "accelerators": [
+        {"kind": "gpu", "vendor": "nvidia", "minComputeCapability": "9.0", "isa": ["sm_90a"]},
+        {"kind": "cpu", "isa": ["avx512f"]}
+      ],
+      "minMemory": 80000000000,
+      "tensorParallelSize": 4
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: false,
+		},
+		// expected failure: unknown accelerator kind
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct"
+  },
+  "config": {
+    "paramSize": "8b",
+    "hardware": {
+      "accelerators": [{"kind": "fpga"}]
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: accelerator kind is missing
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct"
+  },
+  "config": {
+    "paramSize": "8b",
+    "hardware": {
+      "accelerators": [{"vendor": "nvidia"}]
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: compute capability is not major.minor
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct"
+  },
+  "config": {
+    "paramSize": "8b",
+    "hardware": {
+      "accelerators": [{"kind": "gpu", "minComputeCapability": "sm_80"}]
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: tensorParallelSize is zero
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct"
+  },
+  "config": {
+    "paramSize": "8b",
+    "hardware": {
+      "tensorParallelSize": 0
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// valid: lineage
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct",
+    "lineage": {
+      "parents": [
+        {"reference": "registry.example.com/models/xyz-3-8b:3.1", "digest": "sha256:5b0bcabd1ed22e9fb1310cf6c2dec7cdef19f0ad69efa1f392e94a4333501270", "relationship": "adapter"},
+        {"digest": "sha256:5b0bcabd1ed22e9fb1310cf6c2dec7cdef19f0ad69efa1f392e94a4333501270", "relationship": "merged"},
+        {"reference": "registry.example.com/models/xyz-3-70b:3.1", "relationship": "distilled"}
+      ]
+    }
+  },
+  "config": {
+    "paramSize": "8b"
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: false,
+		},
+		// expected failure: parent is neither referenced nor digested
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct",
+    "lineage": {
+      "parents": [{"relationship": "finetune"}]
+    }
+  },
+  "config": {
+    "paramSize": "8b"
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: unknown relationship
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct",
+    "lineage": {
+      "parents": [{"reference": "registry.example.com/models/xyz-3-8b:3.1", "relationship": "copied"}]
+    }
+  },
+  "config": {
+    "paramSize": "8b"
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: invalid digest
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct",
+    "lineage": {
+      "parents": [{"digest": "sha256:1234", "relationship": "finetune"}]
+    }
+  },
+  "config": {
+    "paramSize": "8b"
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// valid: LoRA adapter
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct-lora"
+  },
+  "config": {
+    "paramSize": "8b",
+    "adapter": {
+      "type": "lora",
+      "baseModelDigest": "sha256:5b0bcabd1ed22e9fb1310cf6c2dec7cdef19f0ad69efa1f392e94a4333501270",
+      "baseModelReference": "registry.example.com/models/xyz-3-8b:3.1",
+      "rank": 16,
+      "alpha": 32,
+      "targetModules": ["q_proj", "v_proj"]
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: false,
+		},
+		// expected failure: adapter base model digest is missing
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct-lora"
+  },
+  "config": {
+    "paramSize": "8b",
+    "adapter": {
+      "type": "lora",
+      "rank": 16
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: adapter base model digest is invalid
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct-lora"
+  },
+  "config": {
+    "paramSize": "8b",
+    "adapter": {
+      "type": "lora",
+      "baseModelDigest": "sha256:1234"
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: adapter rank is zero
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct-lora"
+  },
+  "config": {
+    "paramSize": "8b",
+    "adapter": {
+      "type": "lora",
+      "baseModelDigest": "sha256:5b0bcabd1ed22e9fb1310cf6c2dec7cdef19f0ad69efa1f392e94a4333501270",
+      "rank": 0
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// valid: mixture of experts architecture details
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-moe-47B"
+  },
+  "config": {
+    "paramSize": "47b",
+    "architectureDetails": {
+      "numLayers": 32,
+      "hiddenSize": 4096,
+      "numAttentionHeads": 32,
+      "numKeyValueHeads": 8,
+      "vocabSize": 32000,
+      "maxPositionEmbeddings": 32768,
+      "numExperts": 8,
+      "numActiveExperts": 2,
+      "activeParamSize": "13b"
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: false,
+		},
+		// valid: head dimension independent of the hidden size
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-moe-47B"
+  },
+  "config": {
+    "paramSize": "47b",
+    "architectureDetails": {
+      "hiddenSize": 3072,
+      "numAttentionHeads": 10,
+      "headDim": 256
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: false,
+		},
+		// expected failure: attention heads do not divide hidden size
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-moe-47B"
+  },
+  "config": {
+    "paramSize": "47b",
+    "architectureDetails": {
+      "hiddenSize": 4096,
+      "numAttentionHeads": 24
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: key value heads do not divide attention heads
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-moe-47B"
+  },
+  "config": {
+    "paramSize": "47b",
+    "architectureDetails": {
+      "numAttentionHeads": 32,
+      "numKeyValueHeads": 6
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: more active experts than experts
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-moe-47B"
+  },
+  "config": {
+    "paramSize": "47b",
+    "architectureDetails": {
+      "numExperts": 8,
+      "numActiveExperts": 16
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: active parameters exceed parameters
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-moe-47B"
+  },
+  "config": {
+    "paramSize": "47b",
+    "architectureDetails": {
+      "activeParamSize": "70b"
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: zero layers
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-moe-47B"
+  },
+  "config": {
+    "paramSize": "47b",
+    "architectureDetails": {
+      "numLayers": 0
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// valid: structured quantization matching the quantization string
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct-GPTQ"
+  },
+  "config": {
+    "paramSize": "8b",
+    "precision": "float16",
+    "quantization": "gptq-4bit-128g",
+    "quantizationDetails": {
+      "method": "gptq",
+      "bits": 4,
+      "groupSize": 128,
+      "symmetric": false,
+      "excludedModules": ["lm_head"],
+      "calibrationDataset": "wikitext2"
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: false,
+		},
+		// expected failure: quantization method is missing
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct-GPTQ"
+  },
+  "config": {
+    "paramSize": "8b",
+    "precision": "float16",
+    "quantizationDetails": {
+      "bits": 4
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: quantization method does not match the quantization string
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct-GPTQ"
+  },
+  "config": {
+    "paramSize": "8b",
+    "precision": "float16",
+    "quantization": "awq",
+    "quantizationDetails": {
+      "method": "gptq"
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: quantization bits do not match the quantization string
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct-GPTQ"
+  },
+  "config": {
+    "paramSize": "8b",
+    "precision": "float16",
+    "quantization": "gptq-8bit",
+    "quantizationDetails": {
+      "method": "gptq",
+      "bits": 4
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
+		// expected failure: quantization is wider than the precision
+		{
+			config: `
+{
+  "descriptor": {
+    "name": "xyz-3-8B-Instruct-GPTQ"
+  },
+  "config": {
+    "paramSize": "8b",
+    "precision": "float16",
+    "quantizationDetails": {
+      "method": "int",
+      "bits": 32
+    }
+  },
+  "modelfs": {
+    "type": "layers",
+    "diffIds": [
+       "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+    ]
+  }
+}
+`,
+			fail: true,
+		},
 	} {
 		r := strings.NewReader(tt.config)
 		err := schema.ValidatorMediaTypeModelConfig.Validate(r)
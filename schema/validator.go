@@ -122,5 +122,13 @@ func validateConfig(buf []byte) error {
 		return fmt.Errorf("config format mismatch: %w", err)
 	}
 
+	if err := model.Descriptor.Lineage.Validate(); err != nil {
+		return fmt.Errorf("invalid lineage: %w", err)
+	}
+
+	if err := model.Config.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,219 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sniff
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/modelpack/model-spec/content"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+// Weight formats recognized from the first bytes of a file.
+const (
+	FormatSafetensors = "safetensors"
+	FormatGGUF        = "gguf"
+	FormatONNX        = "onnx"
+	FormatPickle      = "pickle"
+	FormatZip         = "zip"
+)
+
+// tarMagicOffset is the offset of the magic field in a ustar header,
+// which holds "ustar\x00" for POSIX archives (including PAX) and "ustar " for GNU archives.
+const tarMagicOffset = 257
+
+// maxSafetensorsHeader bounds the JSON header size of a plausible safetensors file.
+const maxSafetensorsHeader = 100 << 20
+
+// Result describes the packaging of a layer blob as detected from its content.
+type Result struct {
+	// MediaType is the declared media type of the layer.
+	MediaType string
+
+	// Compression is the detected compression, v1.CompressionGzip, v1.CompressionZstd or empty.
+	Compression string
+
+	// Archived reports whether the uncompressed content is a tar archive.
+	// The content of raw layers is not decompressed, it is inspected as is.
+	Archived bool
+
+	// Format is the weight format detected on the raw content, or on the first regular file of an archive,
+	// such as FormatSafetensors. It is empty when the format is not recognized.
+	Format string
+
+	// Filepath is the org.cncf.model.filepath annotation of the layer, set by the caller.
+	// A raw layer may be a tar archive only when its file path ends in ".tar".
+	Filepath string
+}
+
+// Mismatches lists the differences between the declared media type and the detected packaging.
+// It is empty if the content matches its media type. Raw layers hold arbitrary files, which may
+// themselves be compressed, so only a tar archive not named as one is reported for them.
+func (r Result) Mismatches() []string {
+	mt, err := v1.ParseLayerMediaType(r.MediaType)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	if !mt.Archived {
+		if r.Archived && !strings.HasSuffix(r.Filepath, ".tar") {
+			return []string{fmt.Sprintf("declared %s, detected %s", packagingName(false), packagingName(true))}
+		}
+		return nil
+	}
+
+	var mismatches []string
+	if mt.Compression != r.Compression {
+		mismatches = append(mismatches, fmt.Sprintf("declared compression %s, detected %s", compressionName(mt.Compression), compressionName(r.Compression)))
+	}
+	if mt.Archived != r.Archived {
+		mismatches = append(mismatches, fmt.Sprintf("declared %s, detected %s", packagingName(mt.Archived), packagingName(r.Archived)))
+	}
+	return mismatches
+}
+
+// Detect inspects the first bytes of a layer blob read from r, declared with the given media type.
+// Only the beginning of the blob is read.
+func Detect(r io.Reader, mediaType string) (Result, error) {
+	result := Result{MediaType: mediaType}
+
+	br := bufio.NewReader(r)
+	header, err := br.Peek(4)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return result, err
+	}
+	result.Compression = content.DetectCompression(header)
+
+	compression := result.Compression
+	if mt, err := v1.ParseLayerMediaType(mediaType); err == nil && !mt.Archived {
+		compression = ""
+	}
+	zr, err := content.Decompress(br, compression)
+	if err != nil {
+		return result, err
+	}
+	defer zr.Close()
+
+	ur := bufio.NewReaderSize(zr, 1024)
+	block, err := ur.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return result, fmt.Errorf("failed to read %s content: %w", compressionName(compression), err)
+	}
+	if len(block) == 512 && bytes.HasPrefix(block[tarMagicOffset:], []byte("ustar")) {
+		result.Archived = true
+		result.Format, err = firstFileFormat(ur)
+		return result, err
+	}
+
+	result.Format = DetectFormat(block)
+	return result, nil
+}
+
+// firstFileFormat detects the weight format of the first regular file of a tar archive.
+func firstFileFormat(r io.Reader) (string, error) {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return "", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read tar content: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		buf := make([]byte, 16)
+		n, err := io.ReadFull(tr, buf)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		return DetectFormat(buf[:n]), nil
+	}
+}
+
+// DetectFormat returns the weight format of a file starting with header, or an empty string.
+// At least the first 16 bytes of the file should be given.
+func DetectFormat(header []byte) string {
+	switch {
+	case bytes.HasPrefix(header, []byte("GGUF")):
+		return FormatGGUF
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")):
+		// PyTorch stores its weights as a zip archive of pickles since version 1.6
+		return FormatZip
+	case len(header) >= 2 && header[0] == 0x80 && header[1] >= 2 && header[1] <= 5:
+		// PROTO opcode of pickle protocols 2 to 5
+		return FormatPickle
+	case isSafetensors(header):
+		return FormatSafetensors
+	case isONNX(header):
+		return FormatONNX
+	default:
+		return ""
+	}
+}
+
+// isSafetensors checks for a little-endian uint64 header size followed by a JSON object.
+func isSafetensors(header []byte) bool {
+	if len(header) < 9 {
+		return false
+	}
+	size := binary.LittleEndian.Uint64(header)
+	return size >= 2 && size <= maxSafetensorsHeader && header[8] == '{'
+}
+
+// isONNX checks for the protobuf encoding of an ONNX ModelProto, which starts with
+// the ir_version varint (field 1) followed by another known field of the message.
+func isONNX(header []byte) bool {
+	if len(header) < 3 || header[0] != 0x08 {
+		return false
+	}
+	i := 1
+	for i < len(header) && header[i]&0x80 != 0 {
+		i++
+	}
+	i++
+	if i >= len(header) {
+		return false
+	}
+	switch header[i] {
+	// producer_name, producer_version, domain, model_version, doc_string, graph, opset_import
+	case 0x12, 0x1a, 0x22, 0x28, 0x32, 0x3a, 0x42:
+		return true
+	}
+	return false
+}
+
+func compressionName(compression string) string {
+	if compression == "" {
+		return "uncompressed"
+	}
+	return compression
+}
+
+func packagingName(archived bool) string {
+	if archived {
+		return "tar archive"
+	}
+	return "raw file"
+}
@@ -0,0 +1,133 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sniff_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/modelpack/model-spec/sniff"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+func safetensors() []byte {
+	header := []byte(`{"weight":{"dtype":"F16","shape":[1],"data_offsets":[0,2]}}`)
+	buf := binary.LittleEndian.AppendUint64(nil, uint64(len(header)))
+	buf = append(buf, header...)
+	return append(buf, 0, 0)
+}
+
+func tarOf(t *testing.T, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	// a PAX record forces an extended header before the file entry
+	hdr := &tar.Header{
+		Typeflag:   tar.TypeReg,
+		Name:       "model.bin",
+		Mode:       0o644,
+		Size:       int64(len(content)),
+		PAXRecords: map[string]string{"comment": "model"},
+		Format:     tar.FormatPAX,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func gzipOf(t *testing.T, p []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(p); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zstdOf(t *testing.T, p []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := zw.Write(p); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDetect(t *testing.T) {
+	gguf := append([]byte("GGUF"), 3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+	onnx := []byte("\x08\x07\x12\x07pytorch\x1a\x032.0")
+	pickle := []byte("\x80\x02}q\x00(X\x06\x00\x00\x00weight")
+
+	for _, tt := range []struct {
+		name        string
+		mediaType   string
+		blob        []byte
+		compression string
+		archived    bool
+		format      string
+		filepath    string
+		mismatches  int
+	}{
+		{name: "raw safetensors", mediaType: v1.MediaTypeModelWeightRaw, blob: safetensors(), format: sniff.FormatSafetensors},
+		{name: "raw gguf", mediaType: v1.MediaTypeModelWeightRaw, blob: gguf, format: sniff.FormatGGUF},
+		{name: "raw onnx", mediaType: v1.MediaTypeModelWeightRaw, blob: onnx, format: sniff.FormatONNX},
+		{name: "raw pickle", mediaType: v1.MediaTypeModelWeightRaw, blob: pickle, format: sniff.FormatPickle},
+		{name: "tar", mediaType: v1.MediaTypeModelWeight, blob: tarOf(t, gguf), archived: true, format: sniff.FormatGGUF},
+		{name: "tar+gzip", mediaType: v1.MediaTypeModelWeightGzip, blob: gzipOf(t, tarOf(t, safetensors())), compression: v1.CompressionGzip, archived: true, format: sniff.FormatSafetensors},
+		{name: "tar+zstd", mediaType: v1.MediaTypeModelWeightZstd, blob: zstdOf(t, tarOf(t, safetensors())), compression: v1.CompressionZstd, archived: true, format: sniff.FormatSafetensors},
+		{name: "zstd declared gzip", mediaType: v1.MediaTypeModelWeightGzip, blob: zstdOf(t, tarOf(t, gguf)), compression: v1.CompressionZstd, archived: true, format: sniff.FormatGGUF, mismatches: 1},
+		{name: "tar declared raw", mediaType: v1.MediaTypeModelWeightRaw, blob: tarOf(t, gguf), archived: true, format: sniff.FormatGGUF, filepath: "model.gguf", mismatches: 1},
+		{name: "raw tar file", mediaType: v1.MediaTypeModelDatasetRaw, blob: tarOf(t, gguf), archived: true, format: sniff.FormatGGUF, filepath: "data/shard-0.tar"},
+		{name: "gzip file declared raw", mediaType: v1.MediaTypeModelDatasetRaw, blob: gzipOf(t, []byte(`{"text": "x"}`)), compression: v1.CompressionGzip},
+		{name: "raw declared tar+gzip", mediaType: v1.MediaTypeModelWeightGzip, blob: []byte("plain text"), mismatches: 2},
+	} {
+		result, err := sniff.Detect(bytes.NewReader(tt.blob), tt.mediaType)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if result.Compression != tt.compression || result.Archived != tt.archived || result.Format != tt.format {
+			t.Errorf("%s: expected %q/%t/%q, got %q/%t/%q", tt.name, tt.compression, tt.archived, tt.format, result.Compression, result.Archived, result.Format)
+		}
+		result.Filepath = tt.filepath
+		if got := result.Mismatches(); len(got) != tt.mismatches {
+			t.Errorf("%s: expected %d mismatches, got %q", tt.name, tt.mismatches, got)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// Validate checks the adapter description. A nil AdapterConfig is valid.
+func (a *AdapterConfig) Validate() error {
+	if a == nil {
+		return nil
+	}
+
+	var errs []error
+	if a.Type == "" {
+		errs = append(errs, errors.New("type is empty"))
+	}
+	if a.BaseModelDigest == "" {
+		errs = append(errs, errors.New("baseModelDigest is empty"))
+	} else if err := a.BaseModelDigest.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid baseModelDigest: %w", err))
+	}
+	if a.Rank < 0 {
+		errs = append(errs, fmt.Errorf("rank is %d, expected a positive number", a.Rank))
+	}
+	for i, m := range a.TargetModules {
+		if m == "" {
+			errs = append(errs, fmt.Errorf("targetModules %d is empty", i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CompatibleWith checks that the adapter applies to the base model whose manifest has the given digest.
+// The base model must be the one the adapter names, must not itself be an adapter,
+// and must have the same architecture as the adapter when both declare one.
+func (m Model) CompatibleWith(baseDigest digest.Digest, base Model) error {
+	a := m.Config.Adapter
+	if a == nil {
+		return errors.New("model is not an adapter")
+	}
+	if a.BaseModelDigest != baseDigest {
+		return fmt.Errorf("adapter base model is %s, not %s", a.BaseModelDigest, baseDigest)
+	}
+	if base.Config.Adapter != nil {
+		return errors.New("base model is itself an adapter")
+	}
+	if m.Config.Architecture != "" && base.Config.Architecture != "" && m.Config.Architecture != base.Config.Architecture {
+		return fmt.Errorf("adapter architecture %q does not match base model architecture %q", m.Config.Architecture, base.Config.Architecture)
+	}
+	return nil
+}
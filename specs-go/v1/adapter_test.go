@@ -0,0 +1,54 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1_test
+
+import (
+	"testing"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+)
+
+func TestCompatibleWith(t *testing.T) {
+	baseDigest := digest.FromString("base")
+	base := v1.Model{Config: v1.ModelConfig{Architecture: "transformer"}}
+	adapter := v1.Model{Config: v1.ModelConfig{
+		Architecture: "transformer",
+		Adapter:      &v1.AdapterConfig{Type: "lora", BaseModelDigest: baseDigest, Rank: 16},
+	}}
+
+	if err := adapter.CompatibleWith(baseDigest, base); err != nil {
+		t.Errorf("expected compatible adapter, got %v", err)
+	}
+
+	cnn := base
+	cnn.Config.Architecture = "cnn"
+	for i, tt := range []struct {
+		adapter v1.Model
+		digest  digest.Digest
+		base    v1.Model
+	}{
+		{adapter: base, digest: baseDigest, base: base},
+		{adapter: adapter, digest: digest.FromString("other"), base: base},
+		{adapter: adapter, digest: baseDigest, base: adapter},
+		{adapter: adapter, digest: baseDigest, base: cnn},
+	} {
+		if err := tt.adapter.CompatibleWith(tt.digest, tt.base); err == nil {
+			t.Errorf("test %d: expected incompatible adapter", i)
+		}
+	}
+}
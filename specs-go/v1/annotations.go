@@ -29,6 +29,23 @@ const (
 	AnnotationMediaTypeUntested = "org.cncf.model.file.mediatype.untested"
 )
 
+const (
+	// AnnotationVariantPrecision is the annotation key for the precision of the model variant referenced by an index manifest descriptor.
+	AnnotationVariantPrecision = "org.cncf.model.variant.precision"
+
+	// AnnotationVariantQuantization is the annotation key for the quantization of the model variant referenced by an index manifest descriptor.
+	AnnotationVariantQuantization = "org.cncf.model.variant.quantization"
+
+	// AnnotationVariantFormat is the annotation key for the format of the model variant referenced by an index manifest descriptor.
+	AnnotationVariantFormat = "org.cncf.model.variant.format"
+
+	// AnnotationVariantParamSize is the annotation key for the parameter size of the model variant referenced by an index manifest descriptor.
+	AnnotationVariantParamSize = "org.cncf.model.variant.paramSize"
+
+	// AnnotationVariantSize is the annotation key for the total size in bytes of the layers of the model variant referenced by an index manifest descriptor.
+	AnnotationVariantSize = "org.cncf.model.variant.size"
+)
+
 // FileMetadata represents the metadata of file, which is the value definition of AnnotationFileMetadata.
 type FileMetadata struct {
 	// File name
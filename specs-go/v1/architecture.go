@@ -0,0 +1,78 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks the internal consistency of the architecture details. A nil ArchitectureDetails is valid.
+func (d *ArchitectureDetails) Validate() error {
+	if d == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, f := range []struct {
+		name  string
+		value int
+	}{
+		{"numLayers", d.NumLayers},
+		{"hiddenSize", d.HiddenSize},
+		{"numAttentionHeads", d.NumAttentionHeads},
+		{"numKeyValueHeads", d.NumKeyValueHeads},
+		{"headDim", d.HeadDim},
+		{"vocabSize", d.VocabSize},
+		{"maxPositionEmbeddings", d.MaxPositionEmbeddings},
+		{"numExperts", d.NumExperts},
+		{"numActiveExperts", d.NumActiveExperts},
+	} {
+		if f.value < 0 {
+			errs = append(errs, fmt.Errorf("%s is %d, expected a positive number", f.name, f.value))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	if d.HiddenSize > 0 && d.NumAttentionHeads > 0 && d.HeadDim == 0 && d.HiddenSize%d.NumAttentionHeads != 0 {
+		errs = append(errs, fmt.Errorf("numAttentionHeads %d does not divide hiddenSize %d", d.NumAttentionHeads, d.HiddenSize))
+	}
+	if d.NumKeyValueHeads > 0 {
+		switch {
+		case d.NumAttentionHeads == 0:
+			errs = append(errs, errors.New("numKeyValueHeads is set without numAttentionHeads"))
+		case d.NumAttentionHeads%d.NumKeyValueHeads != 0:
+			errs = append(errs, fmt.Errorf("numKeyValueHeads %d does not divide numAttentionHeads %d", d.NumKeyValueHeads, d.NumAttentionHeads))
+		}
+	}
+	if d.NumActiveExperts > 0 {
+		switch {
+		case d.NumExperts == 0:
+			errs = append(errs, errors.New("numActiveExperts is set without numExperts"))
+		case d.NumActiveExperts > d.NumExperts:
+			errs = append(errs, fmt.Errorf("numActiveExperts %d exceeds numExperts %d", d.NumActiveExperts, d.NumExperts))
+		}
+	}
+	if d.ActiveParamSize != "" {
+		if _, err := ParseParamSize(d.ActiveParamSize); err != nil {
+			errs = append(errs, fmt.Errorf("invalid activeParamSize: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
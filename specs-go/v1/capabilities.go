@@ -0,0 +1,136 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Requirements describes the capabilities a request needs from a model.
+// Zero fields do not require anything.
+type Requirements struct {
+	// The input types the model must all accept
+	InputTypes []Modality
+
+	// The output types the model must all produce
+	OutputTypes []Modality
+
+	// Whether the model must (true) or must not (false) perform reasoning tasks
+	Reasoning *bool
+
+	// Whether the model must (true) or must not (false) use external tools
+	ToolUsage *bool
+
+	// Whether the model must (true) or must not (false) be a reward model
+	Reward *bool
+
+	// The languages the model must all speak, encoded as ISO 639 two letter codes
+	Languages []string
+
+	// The earliest knowledge cutoff accepted
+	KnowledgeCutoffAfter *time.Time
+}
+
+// UnmetRequirement describes a requirement which a model does not satisfy.
+type UnmetRequirement struct {
+	// Field is the JSON name of the unmet capability, such as "inputTypes".
+	Field string
+
+	// Reason explains why the requirement is not met.
+	Reason string
+}
+
+func (u UnmetRequirement) String() string {
+	return u.Field + ": " + u.Reason
+}
+
+// Satisfies reports whether the model capabilities meet all the requirements,
+// and lists the unmet ones otherwise. A nil ModelCapabilities declares no capability.
+//
+// Capabilities the model does not declare are treated as unknown: they never meet a requirement
+// for their presence, while an undeclared tri-state capability meets a requirement for its absence.
+func (c *ModelCapabilities) Satisfies(r Requirements) (bool, []UnmetRequirement) {
+	if c == nil {
+		c = &ModelCapabilities{}
+	}
+
+	var unmet []UnmetRequirement
+	add := func(field, format string, args ...any) {
+		unmet = append(unmet, UnmetRequirement{Field: field, Reason: fmt.Sprintf(format, args...)})
+	}
+
+	if missing := missingModalities(c.InputTypes, r.InputTypes); len(missing) > 0 {
+		add("inputTypes", "does not accept %v", missing)
+	}
+	if missing := missingModalities(c.OutputTypes, r.OutputTypes); len(missing) > 0 {
+		add("outputTypes", "does not produce %v", missing)
+	}
+
+	for _, flag := range []struct {
+		field    string
+		has      *bool
+		required *bool
+	}{
+		{field: "reasoning", has: c.Reasoning, required: r.Reasoning},
+		{field: "toolUsage", has: c.ToolUsage, required: r.ToolUsage},
+		{field: "reward", has: c.Reward, required: r.Reward},
+	} {
+		switch {
+		case flag.required == nil:
+		case *flag.required && flag.has == nil:
+			add(flag.field, "required but not declared")
+		case *flag.required && !*flag.has:
+			add(flag.field, "required but not supported")
+		case !*flag.required && flag.has != nil && *flag.has:
+			add(flag.field, "supported but required to be absent")
+		}
+	}
+
+	var languages []string
+	for _, lang := range r.Languages {
+		if !slices.ContainsFunc(c.Languages, func(l string) bool { return strings.EqualFold(l, lang) }) {
+			languages = append(languages, lang)
+		}
+	}
+	if len(languages) > 0 {
+		add("languages", "does not speak %v", languages)
+	}
+
+	if r.KnowledgeCutoffAfter != nil {
+		switch {
+		case c.KnowledgeCutoff == nil:
+			add("knowledgeCutoff", "not declared, required after %s", r.KnowledgeCutoffAfter.Format(time.RFC3339))
+		case c.KnowledgeCutoff.Before(*r.KnowledgeCutoffAfter):
+			add("knowledgeCutoff", "%s is before %s", c.KnowledgeCutoff.Format(time.RFC3339), r.KnowledgeCutoffAfter.Format(time.RFC3339))
+		}
+	}
+
+	return len(unmet) == 0, unmet
+}
+
+func missingModalities(supported, required []Modality) []Modality {
+	var missing []Modality
+	for _, m := range required {
+		if !slices.Contains(supported, m) {
+			missing = append(missing, m)
+		}
+	}
+	return missing
+}
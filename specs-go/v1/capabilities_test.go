@@ -0,0 +1,108 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1_test
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+func TestSatisfies(t *testing.T) {
+	yes, no := true, false
+	cutoff := time.Date(2024, 5, 21, 0, 0, 0, 0, time.UTC)
+	before, after := cutoff.AddDate(0, -1, 0), cutoff.AddDate(0, 1, 0)
+
+	caps := &v1.ModelCapabilities{
+		InputTypes:      []v1.Modality{v1.TextModality, v1.ImageModality},
+		OutputTypes:     []v1.Modality{v1.TextModality},
+		KnowledgeCutoff: &cutoff,
+		Reasoning:       &yes,
+		ToolUsage:       &no,
+		Languages:       []string{"en", "fr"},
+	}
+
+	for _, tt := range []struct {
+		name         string
+		caps         *v1.ModelCapabilities
+		requirements v1.Requirements
+		unmet        []string
+	}{
+		{name: "no requirements", caps: caps},
+		{name: "nil capabilities", caps: nil},
+		{
+			name: "all met",
+			caps: caps,
+			requirements: v1.Requirements{
+				InputTypes:           []v1.Modality{v1.ImageModality},
+				OutputTypes:          []v1.Modality{v1.TextModality},
+				Reasoning:            &yes,
+				ToolUsage:            &no,
+				Reward:               &no,
+				Languages:            []string{"EN"},
+				KnowledgeCutoffAfter: &before,
+			},
+		},
+		{
+			name: "modalities",
+			caps: caps,
+			requirements: v1.Requirements{
+				InputTypes:  []v1.Modality{v1.AudioModality, v1.TextModality},
+				OutputTypes: []v1.Modality{v1.ImageModality},
+			},
+			unmet: []string{"inputTypes", "outputTypes"},
+		},
+		{
+			name: "tri-state capabilities",
+			caps: caps,
+			requirements: v1.Requirements{
+				Reasoning: &no,
+				ToolUsage: &yes,
+				Reward:    &yes,
+			},
+			unmet: []string{"reasoning", "toolUsage", "reward"},
+		},
+		{
+			name:         "languages and knowledge cutoff",
+			caps:         caps,
+			requirements: v1.Requirements{Languages: []string{"en", "zh"}, KnowledgeCutoffAfter: &after},
+			unmet:        []string{"languages", "knowledgeCutoff"},
+		},
+		{
+			name:         "undeclared capabilities",
+			caps:         nil,
+			requirements: v1.Requirements{InputTypes: []v1.Modality{v1.TextModality}, Reasoning: &yes, KnowledgeCutoffAfter: &before},
+			unmet:        []string{"inputTypes", "reasoning", "knowledgeCutoff"},
+		},
+	} {
+		ok, unmet := tt.caps.Satisfies(tt.requirements)
+		if ok != (len(tt.unmet) == 0) {
+			t.Errorf("%s: expected satisfied %t, got %t", tt.name, len(tt.unmet) == 0, ok)
+		}
+		if len(unmet) != len(tt.unmet) {
+			t.Errorf("%s: expected unmet %v, got %v", tt.name, tt.unmet, unmet)
+			continue
+		}
+		for i := range unmet {
+			if unmet[i].Field != tt.unmet[i] {
+				t.Errorf("%s: expected unmet %v, got %v", tt.name, tt.unmet, unmet)
+				break
+			}
+		}
+	}
+}
@@ -28,6 +28,9 @@ type ModelConfig struct {
 	// The model architecture, such as transformer, cnn, rnn, etc.
 	Architecture string `json:"architecture,omitempty"`
 
+	// The structured details of the model architecture
+	ArchitectureDetails *ArchitectureDetails `json:"architectureDetails,omitempty"`
+
 	// The model format, such as onnx, tensorflow, pytorch, etc.
 	Format string `json:"format,omitempty"`
 
@@ -40,8 +43,20 @@ type ModelConfig struct {
 	// The model quantization, such as awq, gptq, etc
 	Quantization string `json:"quantization,omitempty"`
 
+	// The structured description of the model quantization
+	QuantizationDetails *QuantizationDetails `json:"quantizationDetails,omitempty"`
+
 	// Special capabilities that the model supports
 	Capabilities *ModelCapabilities `json:"capabilities,omitempty"`
+
+	// The parameters for running the model with an inference engine
+	Runtime *ModelRuntime `json:"runtime,omitempty"`
+
+	// The hardware required to run the model
+	Hardware *HardwareRequirements `json:"hardware,omitempty"`
+
+	// The adapter description, set when the model is an adapter to apply to a base model
+	Adapter *AdapterConfig `json:"adapter,omitempty"`
 }
 
 // ModelFS describes a layer content addresses
@@ -93,6 +108,47 @@ type ModelDescriptor struct {
 
 	// The human-readable description of the software packaged in the model
 	Description string `json:"description,omitempty"`
+
+	// The models the model derives from
+	Lineage *ModelLineage `json:"lineage,omitempty"`
+}
+
+// Relationship defines how a model derives from a parent model
+type Relationship string
+
+const (
+	// RelationshipFinetune indicates that the model is a fine-tune of the parent model.
+	RelationshipFinetune Relationship = "finetune"
+
+	// RelationshipAdapter indicates that the model is an adapter, such as LoRA, of the parent model.
+	RelationshipAdapter Relationship = "adapter"
+
+	// RelationshipQuantizedFrom indicates that the model is a quantization of the parent model.
+	RelationshipQuantizedFrom Relationship = "quantized-from"
+
+	// RelationshipMerged indicates that the model is a merge of the parent models.
+	RelationshipMerged Relationship = "merged"
+
+	// RelationshipDistilled indicates that the model is distilled from the parent model.
+	RelationshipDistilled Relationship = "distilled"
+)
+
+// ModelLineage defines the models the model derives from
+type ModelLineage struct {
+	// Parents lists the models the model directly derives from
+	Parents []ParentModel `json:"parents,omitempty"`
+}
+
+// ParentModel references a model the model derives from
+type ParentModel struct {
+	// Reference is the OCI reference of the parent model, such as registry.example.com/models/llama3:8b
+	Reference string `json:"reference,omitempty"`
+
+	// Digest is the digest of the manifest of the parent model
+	Digest digest.Digest `json:"digest,omitempty"`
+
+	// Relationship defines how the model derives from the parent model
+	Relationship Relationship `json:"relationship"`
 }
 
 // Modality defines the input and output types of the model
@@ -157,6 +213,177 @@ type ModelCapabilities struct {
 	Languages []string `json:"languages,omitempty"`
 }
 
+// ArchitectureDetails defines the structure of the model architecture
+type ArchitectureDetails struct {
+	// NumLayers is the number of hidden layers
+	NumLayers int `json:"numLayers,omitempty"`
+
+	// HiddenSize is the dimension of the hidden representations
+	HiddenSize int `json:"hiddenSize,omitempty"`
+
+	// NumAttentionHeads is the number of attention heads of each layer
+	NumAttentionHeads int `json:"numAttentionHeads,omitempty"`
+
+	// NumKeyValueHeads is the number of key and value heads of each layer, lower than NumAttentionHeads with grouped query attention
+	NumKeyValueHeads int `json:"numKeyValueHeads,omitempty"`
+
+	// HeadDim is the dimension of the attention heads, when it is not HiddenSize divided by NumAttentionHeads
+	HeadDim int `json:"headDim,omitempty"`
+
+	// VocabSize is the number of tokens of the vocabulary
+	VocabSize int `json:"vocabSize,omitempty"`
+
+	// MaxPositionEmbeddings is the maximum sequence length the position embeddings support
+	MaxPositionEmbeddings int `json:"maxPositionEmbeddings,omitempty"`
+
+	// NumExperts is the total number of experts of each mixture of experts layer
+	NumExperts int `json:"numExperts,omitempty"`
+
+	// NumActiveExperts is the number of experts activated for each token
+	NumActiveExperts int `json:"numActiveExperts,omitempty"`
+
+	// ActiveParamSize is the number of parameters activated for each token, in the format of ParamSize
+	ActiveParamSize string `json:"activeParamSize,omitempty"`
+}
+
+// QuantizationDetails defines how the model is quantized
+type QuantizationDetails struct {
+	// Method is the quantization method, such as gptq, awq, fp8, q4_k_m, etc.
+	Method string `json:"method"`
+
+	// Bits is the number of bits of the quantized weights, zero if not given
+	Bits int `json:"bits,omitempty"`
+
+	// GroupSize is the number of weights sharing the same quantization parameters
+	GroupSize int `json:"groupSize,omitempty"`
+
+	// Symmetric indicates whether the quantization is symmetric (true) or asymmetric (false)
+	Symmetric *bool `json:"symmetric,omitempty"`
+
+	// ExcludedModules lists the modules left unquantized, such as lm_head
+	ExcludedModules []string `json:"excludedModules,omitempty"`
+
+	// CalibrationDataset is the dataset used to calibrate the quantization
+	CalibrationDataset string `json:"calibrationDataset,omitempty"`
+}
+
+// ModelRuntime defines the parameters for running the model with an inference engine
+type ModelRuntime struct {
+	// MaxContextLength is the maximum number of tokens of the context window, prompt and output included
+	MaxContextLength int `json:"maxContextLength,omitempty"`
+
+	// MaxOutputTokens is the maximum number of tokens the model generates in a response
+	MaxOutputTokens int `json:"maxOutputTokens,omitempty"`
+
+	// Sampling defines the default sampling parameters
+	Sampling *SamplingParameters `json:"sampling,omitempty"`
+
+	// StopSequences are the sequences which end the generation, in addition to the end of sequence token
+	StopSequences []string `json:"stopSequences,omitempty"`
+
+	// Engines lists the recommended inference engines, in order of preference
+	Engines []Engine `json:"engines,omitempty"`
+}
+
+// SamplingParameters defines the default sampling parameters of the model
+type SamplingParameters struct {
+	// Temperature scales the logits before sampling, 0 meaning greedy decoding
+	Temperature *float64 `json:"temperature,omitempty"`
+
+	// TopP keeps the smallest set of tokens whose cumulative probability reaches TopP
+	TopP *float64 `json:"topP,omitempty"`
+
+	// TopK keeps the TopK most probable tokens
+	TopK *int `json:"topK,omitempty"`
+
+	// MinP drops the tokens whose probability is below MinP times the one of the most probable token
+	MinP *float64 `json:"minP,omitempty"`
+
+	// RepetitionPenalty penalizes the tokens already generated, 1 meaning no penalty
+	RepetitionPenalty *float64 `json:"repetitionPenalty,omitempty"`
+}
+
+// Engine defines an inference engine recommended to run the model
+type Engine struct {
+	// Name is the name of the engine, such as vllm, sglang, llama.cpp, etc.
+	Name string `json:"name"`
+
+	// MinVersion is the minimum version of the engine supporting the model
+	MinVersion string `json:"minVersion,omitempty"`
+
+	// Flags are the command line flags the engine requires to run the model, such as "--trust-remote-code"
+	Flags []string `json:"flags,omitempty"`
+}
+
+// AcceleratorKind defines the kind of device running the model
+type AcceleratorKind string
+
+const (
+	// AcceleratorGPU indicates a graphics processing unit.
+	AcceleratorGPU AcceleratorKind = "gpu"
+
+	// AcceleratorTPU indicates a tensor processing unit.
+	AcceleratorTPU AcceleratorKind = "tpu"
+
+	// AcceleratorNPU indicates a neural processing unit.
+	AcceleratorNPU AcceleratorKind = "npu"
+
+	// AcceleratorCPU indicates that the model runs on the central processing unit.
+	AcceleratorCPU AcceleratorKind = "cpu"
+
+	// AcceleratorOther indicates a kind of device not explicitly listed.
+	AcceleratorOther AcceleratorKind = "other"
+)
+
+// HardwareRequirements defines the hardware required to run the model
+type HardwareRequirements struct {
+	// Accelerators lists the accelerators able to run the model, any of them being sufficient
+	Accelerators []AcceleratorRequirement `json:"accelerators,omitempty"`
+
+	// MinMemory is the minimum memory in bytes of the devices running the model, summed across devices
+	MinMemory int64 `json:"minMemory,omitempty"`
+
+	// TensorParallelSize is the number of devices the model is sharded across
+	TensorParallelSize int `json:"tensorParallelSize,omitempty"`
+}
+
+// AcceleratorRequirement defines an accelerator able to run the model
+type AcceleratorRequirement struct {
+	// Kind is the kind of accelerator, such as gpu, tpu, npu or cpu
+	Kind AcceleratorKind `json:"kind"`
+
+	// Vendor is the vendor of the accelerator, such as nvidia, amd, intel, etc.
+	Vendor string `json:"vendor,omitempty"`
+
+	// MinComputeCapability is the minimum compute capability of the accelerator as "major.minor", such as "8.0"
+	MinComputeCapability string `json:"minComputeCapability,omitempty"`
+
+	// ISA lists the instruction set architectures and extensions the accelerator must all support,
+	// such as sm_90a, gfx942, avx512f, etc.
+	ISA []string `json:"isa,omitempty"`
+}
+
+// AdapterConfig defines an adapter, such as LoRA, to apply to a base model
+type AdapterConfig struct {
+	// Type is the adapter method, such as lora, dora, ia3, etc.
+	Type string `json:"type"`
+
+	// BaseModelDigest is the digest of the manifest of the base model
+	BaseModelDigest digest.Digest `json:"baseModelDigest"`
+
+	// BaseModelReference is the OCI reference of the base model
+	BaseModelReference string `json:"baseModelReference,omitempty"`
+
+	// Rank is the rank of the low-rank decomposition, for the methods using one
+	Rank int `json:"rank,omitempty"`
+
+	// Alpha is the scaling factor of the adapter, for the methods using one
+	Alpha *float64 `json:"alpha,omitempty"`
+
+	// TargetModules lists the modules of the base model the adapter applies to, such as q_proj, v_proj, etc.
+	TargetModules []string `json:"targetModules,omitempty"`
+}
+
 // Model defines the basic information of a model.
 // It provides the `application/vnd.cncf.model.config.v1+json` mediatype when marshalled to JSON.
 type Model struct {
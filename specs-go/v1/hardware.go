@@ -0,0 +1,163 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Node describes the devices of a machine which may run a model.
+type Node struct {
+	Devices []Device
+}
+
+// Device describes an accelerator of a node.
+type Device struct {
+	// Kind is the kind of the device.
+	Kind AcceleratorKind
+
+	// Vendor is the vendor of the device, such as nvidia.
+	Vendor string
+
+	// ComputeCapability is the compute capability of the device as "major.minor".
+	ComputeCapability string
+
+	// ISA lists the instruction set architectures and extensions the device supports.
+	ISA []string
+
+	// Memory is the memory of the device in bytes.
+	Memory int64
+}
+
+// Validate checks the hardware requirements. A nil HardwareRequirements is valid.
+func (h *HardwareRequirements) Validate() error {
+	if h == nil {
+		return nil
+	}
+
+	var errs []error
+	for i, a := range h.Accelerators {
+		switch a.Kind {
+		case AcceleratorGPU, AcceleratorTPU, AcceleratorNPU, AcceleratorCPU, AcceleratorOther:
+		default:
+			errs = append(errs, fmt.Errorf("accelerators %d: unknown kind %q", i, a.Kind))
+		}
+		if a.MinComputeCapability != "" {
+			if _, err := parseComputeCapability(a.MinComputeCapability); err != nil {
+				errs = append(errs, fmt.Errorf("accelerators %d: %w", i, err))
+			}
+		}
+	}
+	if h.MinMemory < 0 {
+		errs = append(errs, fmt.Errorf("minMemory is %d, expected a positive number of bytes", h.MinMemory))
+	}
+	if h.TensorParallelSize < 0 {
+		errs = append(errs, fmt.Errorf("tensorParallelSize is %d, expected a positive number", h.TensorParallelSize))
+	}
+	return errors.Join(errs...)
+}
+
+// Match reports whether the node can run a model with the hardware requirements,
+// and lists the unmet requirements otherwise. A nil HardwareRequirements matches any node.
+//
+// The node needs TensorParallelSize devices, or one, matching any of the accelerators,
+// and the memory of the largest of them must add up to MinMemory.
+func (h *HardwareRequirements) Match(n Node) (bool, []UnmetRequirement) {
+	if h == nil {
+		return true, nil
+	}
+
+	var devices []Device
+	for _, d := range n.Devices {
+		if len(h.Accelerators) == 0 || slices.ContainsFunc(h.Accelerators, d.satisfies) {
+			devices = append(devices, d)
+		}
+	}
+
+	var unmet []UnmetRequirement
+	needed := max(h.TensorParallelSize, 1)
+	if len(devices) < needed {
+		field := "accelerators"
+		if len(devices) > 0 {
+			field = "tensorParallelSize"
+		}
+		unmet = append(unmet, UnmetRequirement{
+			Field:  field,
+			Reason: fmt.Sprintf("%d matching devices, %d required", len(devices), needed),
+		})
+		return false, unmet
+	}
+
+	if h.MinMemory > 0 {
+		slices.SortStableFunc(devices, func(a, b Device) int { return cmp.Compare(b.Memory, a.Memory) })
+		var memory int64
+		for _, d := range devices[:needed] {
+			memory += d.Memory
+		}
+		if memory < h.MinMemory {
+			unmet = append(unmet, UnmetRequirement{
+				Field:  "minMemory",
+				Reason: fmt.Sprintf("%d bytes on %d devices, %d required", memory, needed, h.MinMemory),
+			})
+		}
+	}
+	return len(unmet) == 0, unmet
+}
+
+// satisfies reports whether the device is an accelerator fulfilling the requirement.
+func (d Device) satisfies(a AcceleratorRequirement) bool {
+	if d.Kind != a.Kind {
+		return false
+	}
+	if a.Vendor != "" && !strings.EqualFold(d.Vendor, a.Vendor) {
+		return false
+	}
+	if a.MinComputeCapability != "" {
+		required, err := parseComputeCapability(a.MinComputeCapability)
+		if err != nil {
+			return false
+		}
+		has, err := parseComputeCapability(d.ComputeCapability)
+		if err != nil || slices.Compare(has, required) < 0 {
+			return false
+		}
+	}
+	for _, isa := range a.ISA {
+		if !slices.ContainsFunc(d.ISA, func(s string) bool { return strings.EqualFold(s, isa) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseComputeCapability parses a "major.minor" compute capability.
+func parseComputeCapability(s string) ([]int, error) {
+	major, minor, ok := strings.Cut(s, ".")
+	if ok {
+		x, errx := strconv.Atoi(major)
+		y, erry := strconv.Atoi(minor)
+		if errx == nil && erry == nil && x >= 0 && y >= 0 {
+			return []int{x, y}, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid compute capability %q: expected major.minor", s)
+}
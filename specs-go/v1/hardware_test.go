@@ -0,0 +1,98 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1_test
+
+import (
+	"testing"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+func TestHardwareMatch(t *testing.T) {
+	a100 := v1.Device{Kind: v1.AcceleratorGPU, Vendor: "NVIDIA", ComputeCapability: "8.0", ISA: []string{"sm_80"}, Memory: 40 << 30}
+	h100 := v1.Device{Kind: v1.AcceleratorGPU, Vendor: "nvidia", ComputeCapability: "9.0", ISA: []string{"sm_90", "sm_90a"}, Memory: 80 << 30}
+	t4 := v1.Device{Kind: v1.AcceleratorGPU, Vendor: "nvidia", ComputeCapability: "7.5", Memory: 16 << 30}
+	cpu := v1.Device{Kind: v1.AcceleratorCPU, ISA: []string{"avx2", "AVX512F"}, Memory: 256 << 30}
+
+	ampere := v1.AcceleratorRequirement{Kind: v1.AcceleratorGPU, Vendor: "nvidia", MinComputeCapability: "8.0"}
+
+	for _, tt := range []struct {
+		name     string
+		hardware *v1.HardwareRequirements
+		node     v1.Node
+		unmet    []string
+	}{
+		{name: "no requirements", hardware: nil, node: v1.Node{}},
+		{
+			name:     "compute capability",
+			hardware: &v1.HardwareRequirements{Accelerators: []v1.AcceleratorRequirement{ampere}},
+			node:     v1.Node{Devices: []v1.Device{t4, a100}},
+		},
+		{
+			name:     "compute capability too low",
+			hardware: &v1.HardwareRequirements{Accelerators: []v1.AcceleratorRequirement{ampere}},
+			node:     v1.Node{Devices: []v1.Device{t4, cpu}},
+			unmet:    []string{"accelerators"},
+		},
+		{
+			name:     "isa",
+			hardware: &v1.HardwareRequirements{Accelerators: []v1.AcceleratorRequirement{{Kind: v1.AcceleratorGPU, ISA: []string{"sm_90a"}}}},
+			node:     v1.Node{Devices: []v1.Device{a100}},
+			unmet:    []string{"accelerators"},
+		},
+		{
+			name: "alternative accelerators",
+			hardware: &v1.HardwareRequirements{Accelerators: []v1.AcceleratorRequirement{
+				{Kind: v1.AcceleratorGPU, ISA: []string{"sm_90a"}},
+				{Kind: v1.AcceleratorCPU, ISA: []string{"avx512f"}},
+			}},
+			node: v1.Node{Devices: []v1.Device{a100, cpu}},
+		},
+		{
+			name:     "tensor parallelism",
+			hardware: &v1.HardwareRequirements{Accelerators: []v1.AcceleratorRequirement{ampere}, TensorParallelSize: 2, MinMemory: 100 << 30},
+			node:     v1.Node{Devices: []v1.Device{a100, t4, h100}},
+		},
+		{
+			name:     "not enough devices",
+			hardware: &v1.HardwareRequirements{Accelerators: []v1.AcceleratorRequirement{ampere}, TensorParallelSize: 4},
+			node:     v1.Node{Devices: []v1.Device{a100, h100, t4}},
+			unmet:    []string{"tensorParallelSize"},
+		},
+		{
+			name:     "not enough memory",
+			hardware: &v1.HardwareRequirements{Accelerators: []v1.AcceleratorRequirement{ampere}, MinMemory: 100 << 30},
+			node:     v1.Node{Devices: []v1.Device{a100, h100}},
+			unmet:    []string{"minMemory"},
+		},
+	} {
+		ok, unmet := tt.hardware.Match(tt.node)
+		if ok != (len(tt.unmet) == 0) {
+			t.Errorf("%s: expected match %t, got %t", tt.name, len(tt.unmet) == 0, ok)
+		}
+		if len(unmet) != len(tt.unmet) {
+			t.Errorf("%s: expected unmet %v, got %v", tt.name, tt.unmet, unmet)
+			continue
+		}
+		for i := range unmet {
+			if unmet[i].Field != tt.unmet[i] {
+				t.Errorf("%s: expected unmet %v, got %v", tt.name, tt.unmet, unmet)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	digest "github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ModelIndex is an OCI image index referencing model manifests.
+// It marshals to the same JSON document as the embedded image index.
+type ModelIndex struct {
+	ocispec.Index
+}
+
+// NewModelIndex returns an image index referencing the given manifests,
+// with the required schemaVersion and mediaType set.
+func NewModelIndex(manifests ...ocispec.Descriptor) ModelIndex {
+	if manifests == nil {
+		manifests = []ocispec.Descriptor{}
+	}
+	return ModelIndex{
+		Index: ocispec.Index{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageIndex,
+			Manifests: manifests,
+		},
+	}
+}
+
+// NewManifestDescriptor returns the descriptor of a model manifest blob with the given digest and size,
+// suitable for a ModelIndex.
+func NewManifestDescriptor(dgst digest.Digest, size int64) ocispec.Descriptor {
+	return ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: ArtifactTypeModelManifest,
+		Digest:       dgst,
+		Size:         size,
+	}
+}
@@ -0,0 +1,48 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks that every parent model is identified and has a known relationship.
+// A nil ModelLineage is valid.
+func (l *ModelLineage) Validate() error {
+	if l == nil {
+		return nil
+	}
+
+	var errs []error
+	for i, p := range l.Parents {
+		if p.Reference == "" && p.Digest == "" {
+			errs = append(errs, fmt.Errorf("parents %d: reference or digest is required", i))
+		}
+		if p.Digest != "" {
+			if err := p.Digest.Validate(); err != nil {
+				errs = append(errs, fmt.Errorf("parents %d: invalid digest: %w", i, err))
+			}
+		}
+		switch p.Relationship {
+		case RelationshipFinetune, RelationshipAdapter, RelationshipQuantizedFrom, RelationshipMerged, RelationshipDistilled:
+		default:
+			errs = append(errs, fmt.Errorf("parents %d: unknown relationship %q", i, p.Relationship))
+		}
+	}
+	return errors.Join(errs...)
+}
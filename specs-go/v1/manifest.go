@@ -0,0 +1,107 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	digest "github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ModelManifest is the OCI image manifest of a model artifact.
+// It marshals to the same JSON document as the embedded image manifest.
+type ModelManifest struct {
+	ocispec.Manifest
+}
+
+// NewModelManifest returns a model manifest referencing the given config and layers,
+// with the required schemaVersion, mediaType and artifactType set.
+func NewModelManifest(config ocispec.Descriptor, layers ...ocispec.Descriptor) ModelManifest {
+	if layers == nil {
+		layers = []ocispec.Descriptor{}
+	}
+	return ModelManifest{
+		Manifest: ocispec.Manifest{
+			Versioned:    specs.Versioned{SchemaVersion: 2},
+			MediaType:    ocispec.MediaTypeImageManifest,
+			ArtifactType: ArtifactTypeModelManifest,
+			Config:       config,
+			Layers:       layers,
+		},
+	}
+}
+
+// NewConfigDescriptor returns the descriptor of a model config blob with the given digest and size.
+func NewConfigDescriptor(dgst digest.Digest, size int64) ocispec.Descriptor {
+	return ocispec.Descriptor{
+		MediaType: MediaTypeModelConfig,
+		Digest:    dgst,
+		Size:      size,
+	}
+}
+
+// NewLayerDescriptor returns the descriptor of a layer blob.
+// The filepath, if not empty, is recorded in the `org.cncf.model.filepath` annotation.
+func NewLayerDescriptor(mediaType string, dgst digest.Digest, size int64, filepath string) ocispec.Descriptor {
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    dgst,
+		Size:      size,
+	}
+	if filepath != "" {
+		desc.Annotations = map[string]string{AnnotationFilepath: filepath}
+	}
+	return desc
+}
+
+// Validate checks the fields required of a model manifest, and that every layer has a model layer media type.
+func (m ModelManifest) Validate() error {
+	var errs []error
+	if m.SchemaVersion != 2 {
+		errs = append(errs, fmt.Errorf("schemaVersion is %d, expected 2", m.SchemaVersion))
+	}
+	if m.MediaType != ocispec.MediaTypeImageManifest {
+		errs = append(errs, fmt.Errorf("mediaType is %q, expected %q", m.MediaType, ocispec.MediaTypeImageManifest))
+	}
+	if m.ArtifactType != ArtifactTypeModelManifest {
+		errs = append(errs, fmt.Errorf("artifactType is %q, expected %q", m.ArtifactType, ArtifactTypeModelManifest))
+	}
+	if m.Config.MediaType != MediaTypeModelConfig {
+		errs = append(errs, fmt.Errorf("config mediaType is %q, expected %q", m.Config.MediaType, MediaTypeModelConfig))
+	}
+	for i, layer := range m.Layers {
+		if _, err := ParseLayerMediaType(layer.MediaType); err != nil {
+			errs = append(errs, fmt.Errorf("layer %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LayersByComponent returns the layers of the given component, such as ComponentWeight,
+// regardless of their packaging and compression, in manifest order.
+func (m ModelManifest) LayersByComponent(component string) []ocispec.Descriptor {
+	var layers []ocispec.Descriptor
+	for _, layer := range m.Layers {
+		if mt, err := ParseLayerMediaType(layer.MediaType); err == nil && mt.Component == component {
+			layers = append(layers, layer)
+		}
+	}
+	return layers
+}
@@ -0,0 +1,109 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestModelManifestJSON(t *testing.T) {
+	manifest := v1.NewModelManifest(
+		v1.NewConfigDescriptor(digest.FromString("config"), 301),
+		v1.NewLayerDescriptor(v1.MediaTypeModelWeight, digest.FromString("weight-1"), 30327160, "model-00001-of-00002.safetensors"),
+		v1.NewLayerDescriptor(v1.MediaTypeModelWeightRaw, digest.FromString("weight-2"), 5018536960, "model-00002-of-00002.safetensors"),
+		v1.NewLayerDescriptor(v1.MediaTypeModelWeightConfig, digest.FromString("config.json"), 106, "config.json"),
+		v1.NewLayerDescriptor(v1.MediaTypeModelDocGzip, digest.FromString("README.md"), 23040, ""),
+	)
+	if err := manifest.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the wrapper marshals to a plain image manifest
+	var raw map[string]any
+	if err := json.Unmarshal(buf, &raw); err != nil {
+		t.Fatal(err)
+	}
+	for key, expected := range map[string]any{
+		"schemaVersion": float64(2),
+		"mediaType":     ocispec.MediaTypeImageManifest,
+		"artifactType":  v1.ArtifactTypeModelManifest,
+	} {
+		if raw[key] != expected {
+			t.Errorf("expected %s to be %v, got %v", key, expected, raw[key])
+		}
+	}
+	if _, ok := raw["Manifest"]; ok {
+		t.Error("expected the embedded manifest to be inlined")
+	}
+
+	var decoded v1.ModelManifest
+	if err := json.Unmarshal(buf, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(manifest, decoded) {
+		t.Errorf("expected round-trip to preserve the manifest:\n%+v\n%+v", manifest, decoded)
+	}
+
+	if got := len(decoded.LayersByComponent(v1.ComponentWeight)); got != 2 {
+		t.Errorf("expected 2 weight layers, got %d", got)
+	}
+	if got := decoded.LayersByComponent(v1.ComponentWeightConfig); len(got) != 1 || got[0].Annotations[v1.AnnotationFilepath] != "config.json" {
+		t.Errorf("expected the config.json layer, got %+v", got)
+	}
+	if got := len(decoded.LayersByComponent(v1.ComponentDataset)); got != 0 {
+		t.Errorf("expected no dataset layers, got %d", got)
+	}
+}
+
+func TestModelManifestValidate(t *testing.T) {
+	manifest := v1.NewModelManifest(ocispec.Descriptor{MediaType: ocispec.MediaTypeImageConfig})
+	manifest.ArtifactType = ""
+	manifest.Layers = append(manifest.Layers, ocispec.Descriptor{MediaType: ocispec.MediaTypeImageLayerGzip})
+	if err := manifest.Validate(); err == nil {
+		t.Error("expected invalid manifest to fail validation")
+	}
+}
+
+func TestModelIndexJSON(t *testing.T) {
+	index := v1.NewModelIndex(v1.NewManifestDescriptor(digest.FromString("manifest"), 1024))
+
+	buf, err := json.Marshal(index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded v1.ModelIndex
+	if err := json.Unmarshal(buf, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(index, decoded) {
+		t.Errorf("expected round-trip to preserve the index:\n%+v\n%+v", index, decoded)
+	}
+	if decoded.MediaType != ocispec.MediaTypeImageIndex || decoded.Manifests[0].ArtifactType != v1.ArtifactTypeModelManifest {
+		t.Errorf("unexpected index %s", buf)
+	}
+}
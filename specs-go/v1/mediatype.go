@@ -16,6 +16,11 @@
 
 package v1
 
+import (
+	"fmt"
+	"strings"
+)
+
 const (
 	// ArtifactTypeModelManifest specifies the artifact type for a model manifest.
 	ArtifactTypeModelManifest = "application/vnd.cncf.model.manifest.v1+json"
@@ -84,4 +89,100 @@ const (
 
 	// MediaTypeModelDatasetZstd specifies the media type for zstd compressed model datasets, including datasets that may be needed throughout the lifecycle of AI/ML models.
 	MediaTypeModelDatasetZstd = "application/vnd.cncf.model.dataset.v1.tar+zstd"
+
+	// MediaTypeModelAdapterRaw is the media type used for an unarchived, uncompressed model adapter, such as LoRA weights to apply to a base model.
+	MediaTypeModelAdapterRaw = "application/vnd.cncf.model.adapter.v1.raw"
+
+	// MediaTypeModelAdapter specifies the media type for model adapters, such as LoRA weights to apply to a base model.
+	MediaTypeModelAdapter = "application/vnd.cncf.model.adapter.v1.tar"
+
+	// MediaTypeModelAdapterGzip specifies the media type for gzipped model adapters, such as LoRA weights to apply to a base model.
+	MediaTypeModelAdapterGzip = "application/vnd.cncf.model.adapter.v1.tar+gzip"
+
+	// MediaTypeModelAdapterZstd specifies the media type for zstd compressed model adapters, such as LoRA weights to apply to a base model.
+	MediaTypeModelAdapterZstd = "application/vnd.cncf.model.adapter.v1.tar+zstd"
 )
+
+const (
+	// ComponentWeight is the layer component for model weights.
+	ComponentWeight = "weight"
+
+	// ComponentWeightConfig is the layer component for configuration of the model weights.
+	ComponentWeightConfig = "weight.config"
+
+	// ComponentDoc is the layer component for model documentation.
+	ComponentDoc = "doc"
+
+	// ComponentCode is the layer component for model code.
+	ComponentCode = "code"
+
+	// ComponentDataset is the layer component for model datasets.
+	ComponentDataset = "dataset"
+
+	// ComponentAdapter is the layer component for model adapters.
+	ComponentAdapter = "adapter"
+)
+
+const (
+	// CompressionGzip is the compression of `+gzip` layer media types.
+	CompressionGzip = "gzip"
+
+	// CompressionZstd is the compression of `+zstd` layer media types.
+	CompressionZstd = "zstd"
+)
+
+const mediaTypeModelPrefix = "application/vnd.cncf.model."
+
+// LayerMediaType is the parsed form of a model layer media type,
+// such as `application/vnd.cncf.model.weight.v1.tar+gzip`.
+type LayerMediaType struct {
+	// Component is the kind of content carried by the layer, such as ComponentWeight.
+	Component string
+
+	// Archived reports whether the layer is a tar archive rather than a single raw file.
+	Archived bool
+
+	// Compression is the compression applied to the tar archive, empty if uncompressed.
+	Compression string
+}
+
+// String returns the media type string of the layer.
+func (m LayerMediaType) String() string {
+	if !m.Archived {
+		return mediaTypeModelPrefix + m.Component + ".v1.raw"
+	}
+	if m.Compression == "" {
+		return mediaTypeModelPrefix + m.Component + ".v1.tar"
+	}
+	return mediaTypeModelPrefix + m.Component + ".v1.tar+" + m.Compression
+}
+
+// ParseLayerMediaType parses a model layer media type into its component, packaging and compression.
+func ParseLayerMediaType(mediaType string) (LayerMediaType, error) {
+	rest, ok := strings.CutPrefix(mediaType, mediaTypeModelPrefix)
+	if !ok {
+		return LayerMediaType{}, fmt.Errorf("%q is not a model layer media type", mediaType)
+	}
+	component, packaging, ok := strings.Cut(rest, ".v1.")
+	if !ok {
+		return LayerMediaType{}, fmt.Errorf("%q is not a model layer media type", mediaType)
+	}
+	switch component {
+	case ComponentWeight, ComponentWeightConfig, ComponentDoc, ComponentCode, ComponentDataset, ComponentAdapter:
+	default:
+		return LayerMediaType{}, fmt.Errorf("unknown layer component %q in media type %q", component, mediaType)
+	}
+
+	m := LayerMediaType{Component: component}
+	switch packaging {
+	case "raw":
+	case "tar":
+		m.Archived = true
+	case "tar+" + CompressionGzip, "tar+" + CompressionZstd:
+		m.Archived = true
+		m.Compression = strings.TrimPrefix(packaging, "tar+")
+	default:
+		return LayerMediaType{}, fmt.Errorf("unknown layer packaging %q in media type %q", packaging, mediaType)
+	}
+	return m, nil
+}
@@ -0,0 +1,95 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// paramSizeRegexp matches the `<count><scale-prefix>` format of ModelConfig.ParamSize.
+var paramSizeRegexp = regexp.MustCompile(`^([0-9]+)(?:\.([0-9]))?([qQtTbBmMkK])$`)
+
+// paramSizeScales maps the scale prefixes of ModelConfig.ParamSize to their multiplier.
+var paramSizeScales = map[byte]uint64{
+	'k': 1e3,
+	'm': 1e6,
+	'b': 1e9,
+	't': 1e12,
+	'q': 1e15,
+}
+
+// ParseParamSize returns the number of parameters denoted by a ModelConfig.ParamSize value,
+// such as "8b" or "6.7B".
+func ParseParamSize(s string) (uint64, error) {
+	m := paramSizeRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid param size %q", s)
+	}
+	count, err := strconv.ParseUint(m[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid param size %q: %w", s, err)
+	}
+	var tenths uint64
+	if m[2] != "" {
+		tenths = uint64(m[2][0] - '0')
+	}
+
+	scale := paramSizeScales[m[3][0]|0x20]
+	if count > (1<<64-1)/scale {
+		return 0, fmt.Errorf("param size %q overflows", s)
+	}
+	return count*scale + tenths*scale/10, nil
+}
+
+// paramSizePrefixes lists the scale prefixes of ModelConfig.ParamSize in increasing order.
+var paramSizePrefixes = []byte{'k', 'm', 'b', 't', 'q'}
+
+// FormatParamSize formats a number of parameters as a ModelConfig.ParamSize value, using the largest
+// scale prefix and rounding to one decimal, such as "8b" for 8030261248 or "6.7b" for 6738415616.
+func FormatParamSize(n uint64) string {
+	var prefix byte
+	var tenths uint64
+	for _, prefix = range paramSizePrefixes {
+		scale := paramSizeScales[prefix]
+		tenths = (n/(scale/20) + 1) / 2
+		if tenths < 10000 {
+			break
+		}
+	}
+	if tenths%10 == 0 {
+		return fmt.Sprintf("%d%c", tenths/10, prefix)
+	}
+	return fmt.Sprintf("%d.%d%c", tenths/10, tenths%10, prefix)
+}
+
+// ParamSizeMatches reports whether a ModelConfig.ParamSize value is n parameters rounded to its last digit,
+// so "8b" and "8.0b" match 8030261248 parameters but "8.1b" does not.
+func ParamSizeMatches(s string, n uint64) (bool, error) {
+	declared, err := ParseParamSize(s)
+	if err != nil {
+		return false, err
+	}
+	m := paramSizeRegexp.FindStringSubmatch(s)
+	unit := paramSizeScales[m[3][0]|0x20]
+	if m[2] != "" {
+		unit /= 10
+	}
+	diff := max(declared, n) - min(declared, n)
+	return diff <= unit/2, nil
+}
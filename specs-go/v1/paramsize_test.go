@@ -0,0 +1,84 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1_test
+
+import (
+	"testing"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+func TestParseParamSize(t *testing.T) {
+	for _, tt := range []struct {
+		size     string
+		expected uint64
+		fail     bool
+	}{
+		{size: "8b", expected: 8e9},
+		{size: "6.7B", expected: 6.7e9},
+		{size: "1.0t", expected: 1e12},
+		{size: "100m", expected: 100e6},
+		{size: "350K", expected: 350e3},
+		{size: "8", fail: true},
+		{size: "6.75b", fail: true},
+		{size: "8g", fail: true},
+		{size: "", fail: true},
+	} {
+		got, err := v1.ParseParamSize(tt.size)
+		if (err != nil) != tt.fail || got != tt.expected {
+			t.Errorf("%q: expected %d (failure %t), got %d, err %v", tt.size, tt.expected, tt.fail, got, err)
+		}
+	}
+}
+
+func TestFormatParamSize(t *testing.T) {
+	for _, tt := range []struct {
+		n        uint64
+		expected string
+	}{
+		{n: 8030261248, expected: "8b"},
+		{n: 6738415616, expected: "6.7b"},
+		{n: 124439808, expected: "124.4m"},
+		{n: 999960000, expected: "1b"},
+		{n: 1e12, expected: "1t"},
+		{n: 350e3, expected: "350k"},
+	} {
+		if got := v1.FormatParamSize(tt.n); got != tt.expected {
+			t.Errorf("%d: expected %q, got %q", tt.n, tt.expected, got)
+		}
+	}
+}
+
+func TestParamSizeMatches(t *testing.T) {
+	for _, tt := range []struct {
+		size     string
+		n        uint64
+		expected bool
+	}{
+		{size: "8b", n: 8030261248, expected: true},
+		{size: "8.0b", n: 8030261248, expected: true},
+		{size: "8.1b", n: 8030261248},
+		{size: "7b", n: 6738415616, expected: true},
+		{size: "6b", n: 6738415616},
+		{size: "124m", n: 124439808, expected: true},
+	} {
+		got, err := v1.ParamSizeMatches(tt.size, tt.n)
+		if err != nil || got != tt.expected {
+			t.Errorf("%q: expected %t for %d parameters, got %t, err %v", tt.size, tt.expected, tt.n, got, err)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// precisionBits maps the precisions to the width in bits of their stored values.
+var precisionBits = map[string]int{
+	"float64":     64,
+	"float32":     32,
+	"float16":     16,
+	"bfloat16":    16,
+	"float8_e4m3": 8,
+	"float8_e5m2": 8,
+	"complex32":   32,
+	"complex64":   64,
+	"complex128":  128,
+	"int8":        8,
+	"int16":       16,
+	"int32":       32,
+	"int64":       64,
+	"uint8":       8,
+	"uint16":      16,
+	"uint32":      32,
+	"uint64":      64,
+	"bool":        8, // stored as one byte
+}
+
+// PrecisionBits returns the width in bits of the values of a precision, such as 16 for bfloat16.
+// The precision name is case-insensitive, false is returned for unknown precisions.
+func PrecisionBits(precision string) (int, bool) {
+	bits, ok := precisionBits[strings.ToLower(strings.TrimSpace(precision))]
+	return bits, ok
+}
+
+var (
+	// ggufQuantizationRegexp matches the GGUF quantization types, such as q4_0, q4_k_m or iq2_xxs.
+	ggufQuantizationRegexp = regexp.MustCompile(`^i?q([1-8])_(0|1|k|k_s|k_m|k_l|s|m|xs|xxs|nl)$`)
+
+	quantizationMethodRegexp = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+	bitsRegexp               = regexp.MustCompile(`^(?:([0-9]+)bits?|int([0-9]+)|w([0-9]+)(?:a[0-9]+)?)$`)
+	groupSizeRegexp          = regexp.MustCompile(`^(?:([0-9]+)g|g([0-9]+))$`)
+)
+
+// ParseQuantization derives a quantization description from a quantization string made of a method
+// followed by dash-separated attributes, such as `gptq-4bit-128g`, `awq-w4a16-g128-asym` or `fp8`,
+// or from a GGUF quantization type, such as `q4_k_m`.
+//
+// The recognized attributes are the bits (`4bit`, `4bits`, `int4`, `w4a16`), the group size (`128g`, `g128`)
+// and the symmetry (`sym`, `asym`). Methods implying a number of bits, such as fp8 or nf4, set it.
+func ParseQuantization(s string) (QuantizationDetails, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if m := ggufQuantizationRegexp.FindStringSubmatch(s); m != nil {
+		q := QuantizationDetails{Method: s}
+		q.Bits, _ = strconv.Atoi(m[1])
+		// legacy GGUF types quantize blocks of 32 weights, with an offset for the _1 types
+		if m[2] == "0" || m[2] == "1" {
+			symmetric := m[2] == "0"
+			q.GroupSize, q.Symmetric = 32, &symmetric
+		}
+		return q, nil
+	}
+
+	method, rest, hasAttributes := strings.Cut(s, "-")
+	if !quantizationMethodRegexp.MatchString(method) {
+		return QuantizationDetails{}, fmt.Errorf("invalid quantization %q: expected a method such as gptq", s)
+	}
+	q := QuantizationDetails{Method: method}
+	if m := bitsRegexp.FindStringSubmatch(method); m != nil {
+		// the method is a number of bits, such as int8
+		q.Bits = firstNumber(m[1:])
+	}
+	switch method {
+	case "fp8":
+		q.Bits = 8
+	case "nf4", "fp4":
+		q.Bits = 4
+	}
+
+	if !hasAttributes {
+		return q, nil
+	}
+	for _, attr := range strings.Split(rest, "-") {
+		if m := bitsRegexp.FindStringSubmatch(attr); m != nil {
+			q.Bits = firstNumber(m[1:])
+			continue
+		}
+		if m := groupSizeRegexp.FindStringSubmatch(attr); m != nil {
+			q.GroupSize = firstNumber(m[1:])
+			continue
+		}
+		switch attr {
+		case "sym", "asym":
+			symmetric := attr == "sym"
+			q.Symmetric = &symmetric
+		default:
+			return QuantizationDetails{}, fmt.Errorf("invalid quantization %q: unknown attribute %q", s, attr)
+		}
+	}
+	return q, nil
+}
+
+// firstNumber returns the first non-empty submatch as a number.
+func firstNumber(submatches []string) int {
+	for _, s := range submatches {
+		if s != "" {
+			n, _ := strconv.Atoi(s)
+			return n
+		}
+	}
+	return 0
+}
+
+// Validate checks the quantization description. A nil QuantizationDetails is valid.
+func (q *QuantizationDetails) Validate() error {
+	if q == nil {
+		return nil
+	}
+
+	var errs []error
+	if q.Method == "" {
+		errs = append(errs, errors.New("method is empty"))
+	}
+	// zero bits means the width is not given
+	if q.Bits < 0 || q.Bits > 64 {
+		errs = append(errs, fmt.Errorf("bits is %d, expected a number between 1 and 64", q.Bits))
+	}
+	if q.GroupSize < 0 {
+		errs = append(errs, fmt.Errorf("groupSize is %d, expected a positive number", q.GroupSize))
+	}
+	for i, m := range q.ExcludedModules {
+		if m == "" {
+			errs = append(errs, fmt.Errorf("excludedModules %d is empty", i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateQuantization checks that the quantization description agrees with the quantization string,
+// when it can be parsed, and that the quantized weights are not wider than the precision.
+func (c ModelConfig) validateQuantization() error {
+	q := c.QuantizationDetails
+	if q == nil {
+		return nil
+	}
+
+	var errs []error
+	if c.Quantization != "" {
+		if parsed, err := ParseQuantization(c.Quantization); err == nil {
+			if !strings.EqualFold(parsed.Method, q.Method) {
+				errs = append(errs, fmt.Errorf("quantizationDetails method %q does not match quantization %q", q.Method, c.Quantization))
+			}
+			if parsed.Bits != 0 && q.Bits != 0 && parsed.Bits != q.Bits {
+				errs = append(errs, fmt.Errorf("quantizationDetails bits %d does not match quantization %q", q.Bits, c.Quantization))
+			}
+		}
+	}
+
+	if q.Bits > 0 && c.Precision != "" {
+		widest := 0
+		for _, p := range strings.Split(c.Precision, ",") {
+			bits, _ := PrecisionBits(p)
+			widest = max(widest, bits)
+		}
+		if widest > 0 && q.Bits > widest {
+			errs = append(errs, fmt.Errorf("quantizationDetails bits %d exceeds precision %s", q.Bits, c.Precision))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,75 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1_test
+
+import (
+	"testing"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+)
+
+func TestParseQuantization(t *testing.T) {
+	yes, no := true, false
+	for _, tt := range []struct {
+		s        string
+		expected v1.QuantizationDetails
+		fail     bool
+	}{
+		{s: "gptq-4bit-128g", expected: v1.QuantizationDetails{Method: "gptq", Bits: 4, GroupSize: 128}},
+		{s: "AWQ-w4a16-g128-asym", expected: v1.QuantizationDetails{Method: "awq", Bits: 4, GroupSize: 128, Symmetric: &no}},
+		{s: "gptq-int8-sym", expected: v1.QuantizationDetails{Method: "gptq", Bits: 8, Symmetric: &yes}},
+		{s: "awq", expected: v1.QuantizationDetails{Method: "awq"}},
+		{s: "fp8", expected: v1.QuantizationDetails{Method: "fp8", Bits: 8}},
+		{s: "nf4", expected: v1.QuantizationDetails{Method: "nf4", Bits: 4}},
+		{s: "int8", expected: v1.QuantizationDetails{Method: "int8", Bits: 8}},
+		{s: "q4_k_m", expected: v1.QuantizationDetails{Method: "q4_k_m", Bits: 4}},
+		{s: "Q8_0", expected: v1.QuantizationDetails{Method: "q8_0", Bits: 8, GroupSize: 32, Symmetric: &yes}},
+		{s: "q4_1", expected: v1.QuantizationDetails{Method: "q4_1", Bits: 4, GroupSize: 32, Symmetric: &no}},
+		{s: "iq2_xxs", expected: v1.QuantizationDetails{Method: "iq2_xxs", Bits: 2}},
+		{s: "gptq-4bit-fast", fail: true},
+		{s: "gptq-", fail: true},
+		{s: "4bit", fail: true},
+		{s: "", fail: true},
+	} {
+		got, err := v1.ParseQuantization(tt.s)
+		if (err != nil) != tt.fail {
+			t.Errorf("%q: expected failure %t, got %v", tt.s, tt.fail, err)
+			continue
+		}
+		if got.Method != tt.expected.Method || got.Bits != tt.expected.Bits || got.GroupSize != tt.expected.GroupSize ||
+			(got.Symmetric == nil) != (tt.expected.Symmetric == nil) || got.Symmetric != nil && *got.Symmetric != *tt.expected.Symmetric {
+			t.Errorf("%q: expected %+v, got %+v", tt.s, tt.expected, got)
+		}
+	}
+}
+
+func TestPrecisionBits(t *testing.T) {
+	for _, tt := range []struct {
+		precision string
+		bits      int
+		ok        bool
+	}{
+		{precision: "bfloat16", bits: 16, ok: true},
+		{precision: " Float8_E4M3", bits: 8, ok: true},
+		{precision: "bool", bits: 8, ok: true},
+		{precision: "mixed"},
+	} {
+		if bits, ok := v1.PrecisionBits(tt.precision); bits != tt.bits || ok != tt.ok {
+			t.Errorf("%q: expected %d/%t, got %d/%t", tt.precision, tt.bits, tt.ok, bits, ok)
+		}
+	}
+}
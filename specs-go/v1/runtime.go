@@ -0,0 +1,78 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks the consistency of the runtime parameters. A nil ModelRuntime is valid.
+func (r *ModelRuntime) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	var errs []error
+	if r.MaxContextLength < 0 {
+		errs = append(errs, fmt.Errorf("maxContextLength is %d, expected a positive number", r.MaxContextLength))
+	}
+	if r.MaxOutputTokens < 0 {
+		errs = append(errs, fmt.Errorf("maxOutputTokens is %d, expected a positive number", r.MaxOutputTokens))
+	}
+	if r.MaxContextLength > 0 && r.MaxOutputTokens > r.MaxContextLength {
+		errs = append(errs, fmt.Errorf("maxOutputTokens %d exceeds maxContextLength %d", r.MaxOutputTokens, r.MaxContextLength))
+	}
+	if r.Sampling != nil {
+		errs = append(errs, r.Sampling.validate())
+	}
+	for i, s := range r.StopSequences {
+		if s == "" {
+			errs = append(errs, fmt.Errorf("stopSequences %d is empty", i))
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, e := range r.Engines {
+		if e.Name == "" {
+			errs = append(errs, fmt.Errorf("engines %d: name is empty", i))
+			continue
+		}
+		if seen[e.Name] {
+			errs = append(errs, fmt.Errorf("engines %d: duplicate engine %q", i, e.Name))
+		}
+		seen[e.Name] = true
+	}
+	return errors.Join(errs...)
+}
+
+func (s *SamplingParameters) validate() error {
+	var errs []error
+	check := func(name string, v *float64, ok func(float64) bool, expected string) {
+		if v != nil && !ok(*v) {
+			errs = append(errs, fmt.Errorf("sampling %s is %g, expected %s", name, *v, expected))
+		}
+	}
+	check("temperature", s.Temperature, func(v float64) bool { return v >= 0 }, "a non-negative number")
+	check("topP", s.TopP, func(v float64) bool { return v > 0 && v <= 1 }, "a number in (0, 1]")
+	check("minP", s.MinP, func(v float64) bool { return v >= 0 && v <= 1 }, "a number in [0, 1]")
+	check("repetitionPenalty", s.RepetitionPenalty, func(v float64) bool { return v > 0 }, "a positive number")
+	if s.TopK != nil && *s.TopK < 1 {
+		errs = append(errs, fmt.Errorf("sampling topK is %d, expected a positive number", *s.TopK))
+	}
+	return errors.Join(errs...)
+}
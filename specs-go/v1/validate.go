@@ -0,0 +1,51 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks the consistency of the optional sections of the model config and across them.
+func (c ModelConfig) Validate() error {
+	var errs []error
+	if err := c.ArchitectureDetails.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid architectureDetails: %w", err))
+	} else if c.ArchitectureDetails != nil && c.ArchitectureDetails.ActiveParamSize != "" && c.ParamSize != "" {
+		active, _ := ParseParamSize(c.ArchitectureDetails.ActiveParamSize)
+		total, err := ParseParamSize(c.ParamSize)
+		if err == nil && active > total {
+			errs = append(errs, fmt.Errorf("activeParamSize %s exceeds paramSize %s", c.ArchitectureDetails.ActiveParamSize, c.ParamSize))
+		}
+	}
+	if err := c.QuantizationDetails.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid quantizationDetails: %w", err))
+	} else if err := c.validateQuantization(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.Runtime.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid runtime: %w", err))
+	}
+	if err := c.Hardware.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid hardware: %w", err))
+	}
+	if err := c.Adapter.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid adapter: %w", err))
+	}
+	return errors.Join(errs...)
+}
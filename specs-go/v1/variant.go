@@ -0,0 +1,206 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ModelVariant describes one variant of a model in a ModelIndex,
+// which is the value definition of the `org.cncf.model.variant.*` annotations.
+type ModelVariant struct {
+	// The model precision, such as bfloat16, float8_e4m3, etc.
+	Precision string
+
+	// The model quantization, such as gptq, q4_k_m, etc.
+	Quantization string
+
+	// The model format, such as safetensors, gguf, etc.
+	Format string
+
+	// The size of the model parameters, such as "8b", "70b", etc.
+	ParamSize string
+
+	// The total size in bytes of the layers of the variant, zero if unknown.
+	Size int64
+}
+
+// Annotations returns the `org.cncf.model.variant.*` annotations describing the variant.
+func (v ModelVariant) Annotations() map[string]string {
+	annotations := make(map[string]string)
+	for key, value := range map[string]string{
+		AnnotationVariantPrecision:    v.Precision,
+		AnnotationVariantQuantization: v.Quantization,
+		AnnotationVariantFormat:       v.Format,
+		AnnotationVariantParamSize:    v.ParamSize,
+	} {
+		if value != "" {
+			annotations[key] = value
+		}
+	}
+	if v.Size > 0 {
+		annotations[AnnotationVariantSize] = strconv.FormatInt(v.Size, 10)
+	}
+	return annotations
+}
+
+// ParseModelVariant reads the `org.cncf.model.variant.*` annotations of an index manifest descriptor.
+func ParseModelVariant(annotations map[string]string) (ModelVariant, error) {
+	v := ModelVariant{
+		Precision:    annotations[AnnotationVariantPrecision],
+		Quantization: annotations[AnnotationVariantQuantization],
+		Format:       annotations[AnnotationVariantFormat],
+		ParamSize:    annotations[AnnotationVariantParamSize],
+	}
+	if v.ParamSize != "" {
+		if _, err := ParseParamSize(v.ParamSize); err != nil {
+			return v, fmt.Errorf("invalid %s annotation: %w", AnnotationVariantParamSize, err)
+		}
+	}
+	if size, ok := annotations[AnnotationVariantSize]; ok {
+		n, err := strconv.ParseInt(size, 10, 64)
+		if err != nil || n <= 0 {
+			return v, fmt.Errorf("invalid %s annotation %q: expected a positive number of bytes", AnnotationVariantSize, size)
+		}
+		v.Size = n
+	}
+	return v, nil
+}
+
+// NewVariantDescriptor returns desc with the annotations describing the variant added.
+func NewVariantDescriptor(desc ocispec.Descriptor, variant ModelVariant) ocispec.Descriptor {
+	annotations := maps.Clone(desc.Annotations)
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	maps.Copy(annotations, variant.Annotations())
+	desc.Annotations = annotations
+	return desc
+}
+
+// Validate checks that the index is a valid index of model variants: every manifest is a model manifest
+// described by at least one valid variant annotation, and no two manifests describe the same variant.
+func (m ModelIndex) Validate() error {
+	var errs []error
+	if m.SchemaVersion != 2 {
+		errs = append(errs, fmt.Errorf("schemaVersion is %d, expected 2", m.SchemaVersion))
+	}
+	if m.MediaType != ocispec.MediaTypeImageIndex {
+		errs = append(errs, fmt.Errorf("mediaType is %q, expected %q", m.MediaType, ocispec.MediaTypeImageIndex))
+	}
+	if len(m.Manifests) == 0 {
+		errs = append(errs, errors.New("index has no manifests"))
+	}
+
+	seen := make(map[ModelVariant]int)
+	for i, desc := range m.Manifests {
+		if desc.MediaType != ocispec.MediaTypeImageManifest {
+			errs = append(errs, fmt.Errorf("manifest %d: mediaType is %q, expected %q", i, desc.MediaType, ocispec.MediaTypeImageManifest))
+		}
+		if desc.ArtifactType != ArtifactTypeModelManifest {
+			errs = append(errs, fmt.Errorf("manifest %d: artifactType is %q, expected %q", i, desc.ArtifactType, ArtifactTypeModelManifest))
+		}
+		variant, err := ParseModelVariant(desc.Annotations)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("manifest %d: %w", i, err))
+			continue
+		}
+		// the size does not distinguish variants
+		key := variant
+		key.Size = 0
+		if key == (ModelVariant{}) {
+			errs = append(errs, fmt.Errorf("manifest %d: no variant annotations", i))
+			continue
+		}
+		if j, ok := seen[key]; ok {
+			errs = append(errs, fmt.Errorf("manifest %d: same variant as manifest %d", i, j))
+			continue
+		}
+		seen[key] = i
+	}
+	return errors.Join(errs...)
+}
+
+// VariantConstraints restricts the variants SelectVariant may pick.
+// Empty fields do not restrict the selection.
+type VariantConstraints struct {
+	// Formats lists the accepted formats, in order of preference.
+	Formats []string
+
+	// Precisions lists the accepted precisions.
+	Precisions []string
+
+	// Quantizations lists the accepted quantizations, "" accepting unquantized variants.
+	Quantizations []string
+
+	// MaxSize is the memory budget in bytes, variants of unknown size are rejected when set.
+	MaxSize int64
+}
+
+// SelectVariant returns the manifest descriptor of the best variant satisfying the constraints.
+// The best variant is the largest one fitting the memory budget, assuming a larger size means
+// a higher precision. Ties are broken by the order of preference of Formats, then by index order.
+func (m ModelIndex) SelectVariant(c VariantConstraints) (ocispec.Descriptor, error) {
+	best := -1
+	var bestVariant ModelVariant
+	for i, desc := range m.Manifests {
+		if desc.ArtifactType != ArtifactTypeModelManifest {
+			continue
+		}
+		variant, err := ParseModelVariant(desc.Annotations)
+		if err != nil || !c.accepts(variant) {
+			continue
+		}
+		if best < 0 || c.better(variant, bestVariant) {
+			best, bestVariant = i, variant
+		}
+	}
+	if best < 0 {
+		return ocispec.Descriptor{}, errors.New("no variant satisfies the constraints")
+	}
+	return m.Manifests[best], nil
+}
+
+func (c VariantConstraints) accepts(v ModelVariant) bool {
+	if len(c.Formats) > 0 && !slices.Contains(c.Formats, v.Format) {
+		return false
+	}
+	if len(c.Precisions) > 0 && !slices.Contains(c.Precisions, v.Precision) {
+		return false
+	}
+	if len(c.Quantizations) > 0 && !slices.Contains(c.Quantizations, v.Quantization) {
+		return false
+	}
+	if c.MaxSize > 0 && (v.Size == 0 || v.Size > c.MaxSize) {
+		return false
+	}
+	return true
+}
+
+// better reports whether v is preferred over w.
+func (c VariantConstraints) better(v, w ModelVariant) bool {
+	if v.Size != w.Size {
+		return v.Size > w.Size
+	}
+	return slices.Index(c.Formats, v.Format) < slices.Index(c.Formats, w.Format)
+}
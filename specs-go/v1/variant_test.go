@@ -0,0 +1,99 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1_test
+
+import (
+	"testing"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func variantIndex() v1.ModelIndex {
+	variant := func(name string, v v1.ModelVariant) ocispec.Descriptor {
+		return v1.NewVariantDescriptor(v1.NewManifestDescriptor(digest.FromString(name), 1024), v)
+	}
+	return v1.NewModelIndex(
+		variant("bf16", v1.ModelVariant{Precision: "bfloat16", Format: "safetensors", ParamSize: "8b", Size: 16 << 30}),
+		variant("fp8", v1.ModelVariant{Precision: "float8_e4m3", Format: "safetensors", ParamSize: "8b", Size: 8 << 30}),
+		variant("q8_0", v1.ModelVariant{Quantization: "q8_0", Format: "gguf", ParamSize: "8b", Size: 8 << 30}),
+		variant("q4_k_m", v1.ModelVariant{Quantization: "q4_k_m", Format: "gguf", ParamSize: "8b", Size: 5 << 30}),
+	)
+}
+
+func TestModelIndexValidate(t *testing.T) {
+	if err := variantIndex().Validate(); err != nil {
+		t.Errorf("expected valid index, got %v", err)
+	}
+
+	for name, mutate := range map[string]func(*v1.ModelIndex){
+		"duplicate variant": func(m *v1.ModelIndex) {
+			m.Manifests[1].Annotations = m.Manifests[0].Annotations
+		},
+		"missing annotations": func(m *v1.ModelIndex) {
+			m.Manifests[0].Annotations = nil
+		},
+		"invalid size": func(m *v1.ModelIndex) {
+			m.Manifests[0].Annotations = map[string]string{v1.AnnotationVariantFormat: "gguf", v1.AnnotationVariantSize: "5GB"}
+		},
+		"not a model manifest": func(m *v1.ModelIndex) {
+			m.Manifests[0].ArtifactType = ""
+		},
+		"empty": func(m *v1.ModelIndex) {
+			m.Manifests = nil
+		},
+	} {
+		index := variantIndex()
+		mutate(&index)
+		if err := index.Validate(); err == nil {
+			t.Errorf("%s: expected validation failure", name)
+		}
+	}
+}
+
+func TestSelectVariant(t *testing.T) {
+	index := variantIndex()
+
+	for _, tt := range []struct {
+		name        string
+		constraints v1.VariantConstraints
+		expected    string
+	}{
+		{name: "unconstrained", expected: "bf16"},
+		{name: "memory budget", constraints: v1.VariantConstraints{MaxSize: 10 << 30}, expected: "fp8"},
+		{name: "preferred format", constraints: v1.VariantConstraints{Formats: []string{"gguf", "safetensors"}, MaxSize: 10 << 30}, expected: "q8_0"},
+		{name: "gguf only", constraints: v1.VariantConstraints{Formats: []string{"gguf"}, MaxSize: 6 << 30}, expected: "q4_k_m"},
+		{name: "quantization", constraints: v1.VariantConstraints{Quantizations: []string{"q4_k_m"}}, expected: "q4_k_m"},
+		{name: "unsatisfiable", constraints: v1.VariantConstraints{MaxSize: 1 << 30}},
+	} {
+		desc, err := index.SelectVariant(tt.constraints)
+		if tt.expected == "" {
+			if err == nil {
+				t.Errorf("%s: expected no variant, got %s", tt.name, desc.Digest)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if desc.Digest != digest.FromString(tt.expected) {
+			t.Errorf("%s: expected variant %s, got %s", tt.name, tt.expected, desc.Annotations)
+		}
+	}
+}
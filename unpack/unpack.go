@@ -0,0 +1,383 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package unpack
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelpack/model-spec/content"
+	"github.com/modelpack/model-spec/layout"
+	"github.com/modelpack/model-spec/safepath"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Options configures how a model artifact is unpacked.
+type Options struct {
+	// Reference selects the manifest to unpack from the layout index,
+	// either by digest or by `org.opencontainers.image.ref.name` annotation.
+	// It may be empty when the index holds a single manifest.
+	Reference string
+
+	// Filter selects the layers to unpack. All layers are unpacked when nil.
+	Filter func(ocispec.Descriptor) bool
+
+	// IgnoreOwnership skips restoring the uid and gid of the unpacked files.
+	IgnoreOwnership bool
+
+	// Checker validates the paths of the unpacked files.
+	// Sharing a Checker across layers rejects files written by more than one layer,
+	// a new Checker is used for every call when nil.
+	Checker *safepath.Checker
+
+	// created records the entries written for the layer being unpacked, when not nil.
+	created *created
+}
+
+// created lists the entries written to dest, so the entries of a layer that fails can be removed.
+type created []string
+
+func (c *created) add(path string) {
+	if c != nil {
+		*c = append(*c, path)
+	}
+}
+
+// mkdirAll creates a directory along with its parents, recording the directories it creates.
+func (c *created) mkdirAll(dir string) error {
+	var missing []string
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Lstat(d); !errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		missing = append(missing, d)
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for i := len(missing) - 1; i >= 0; i-- {
+		c.add(missing[i])
+	}
+	return nil
+}
+
+// remove removes the recorded entries, the last written first.
+func (c created) remove() error {
+	var errs []error
+	for i := len(c) - 1; i >= 0; i-- {
+		if err := os.Remove(c[i]); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ByMediaType returns a filter selecting the layers with one of the given media types.
+func ByMediaType(mediaTypes ...string) func(ocispec.Descriptor) bool {
+	return func(desc ocispec.Descriptor) bool {
+		return slices.Contains(mediaTypes, desc.MediaType)
+	}
+}
+
+// ByComponent returns a filter selecting the layers of the given components, such as v1.ComponentWeight,
+// regardless of their packaging and compression.
+func ByComponent(components ...string) func(ocispec.Descriptor) bool {
+	return func(desc ocispec.Descriptor) bool {
+		mt, err := v1.ParseLayerMediaType(desc.MediaType)
+		return err == nil && slices.Contains(components, mt.Component)
+	}
+}
+
+// Unpack extracts the layers of the model artifact stored in the OCI image layout at src into the dest directory.
+// The entries of a layer that fails, such as on a digest mismatch, are removed from dest.
+func Unpack(src, dest string, opts Options) error {
+	l, err := layout.Open(src)
+	if err != nil {
+		return err
+	}
+	desc, err := l.Resolve(opts.Reference)
+	if err != nil {
+		return err
+	}
+	manifest, err := l.Manifest(desc)
+	if err != nil {
+		return err
+	}
+	if manifest.ArtifactType != v1.ArtifactTypeModelManifest {
+		return fmt.Errorf("manifest %s has artifact type %q, expected %q", desc.Digest, manifest.ArtifactType, v1.ArtifactTypeModelManifest)
+	}
+	if err := safepath.ValidateManifest(manifest); err != nil {
+		return fmt.Errorf("manifest %s has unsafe paths: %w", desc.Digest, err)
+	}
+	if opts.Checker == nil {
+		opts.Checker = safepath.NewChecker()
+	}
+
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return fmt.Errorf("failed to create destination %s: %w", dest, err)
+	}
+	for _, layer := range manifest.Layers {
+		if opts.Filter != nil && !opts.Filter(layer) {
+			continue
+		}
+		if err := unpackBlob(l, layer, dest, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unpackBlob extracts a layer, verifying its digest. The entries written for the layer are removed
+// when it fails, as its content is only verified once it has been read entirely.
+func unpackBlob(l *layout.Layout, desc ocispec.Descriptor, dest string, opts Options) error {
+	rc, err := l.Blob(desc)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	opts.created = &created{}
+	err = Layer(rc, desc, dest, opts)
+	if err == nil {
+		// drain the blob so its digest is verified even if the layer stopped reading early
+		if _, err = io.Copy(io.Discard, rc); err != nil {
+			err = fmt.Errorf("failed to read layer %s: %w", desc.Digest, err)
+		}
+	}
+	if err != nil {
+		if rerr := opts.created.remove(); rerr != nil {
+			return errors.Join(err, fmt.Errorf("failed to remove the entries of layer %s: %w", desc.Digest, rerr))
+		}
+		return err
+	}
+	return nil
+}
+
+// Layer extracts a single layer read from r into the dest directory.
+// Raw layers are written to their `org.cncf.model.filepath` annotation,
+// tar layers are extracted with their entries relative to dest.
+// The reader is not verified against the descriptor digest.
+func Layer(r io.Reader, desc ocispec.Descriptor, dest string, opts Options) error {
+	if opts.Checker == nil {
+		opts.Checker = safepath.NewChecker()
+	}
+	mt, err := v1.ParseLayerMediaType(desc.MediaType)
+	if err != nil {
+		return err
+	}
+	if !mt.Archived {
+		return unpackRaw(r, desc, dest, opts)
+	}
+
+	zr, err := content.Decompress(r, mt.Compression)
+	if err != nil {
+		return fmt.Errorf("failed to read layer %s: %w", desc.Digest, err)
+	}
+	defer zr.Close()
+
+	if err := unpackTar(zr, dest, opts); err != nil {
+		return fmt.Errorf("failed to unpack layer %s: %w", desc.Digest, err)
+	}
+	return nil
+}
+
+func unpackRaw(r io.Reader, desc ocispec.Descriptor, dest string, opts Options) error {
+	name := desc.Annotations[v1.AnnotationFilepath]
+	if name == "" {
+		return fmt.Errorf("raw layer %s has no %s annotation", desc.Digest, v1.AnnotationFilepath)
+	}
+	if err := opts.Checker.CheckPath(name); err != nil {
+		return fmt.Errorf("raw layer %s: %w", desc.Digest, err)
+	}
+	target, err := targetPath(dest, name)
+	if err != nil {
+		return err
+	}
+
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o644,
+	}
+	if raw, ok := desc.Annotations[v1.AnnotationFileMetadata]; ok {
+		var metadata v1.FileMetadata
+		if err := json.Unmarshal([]byte(raw), &metadata); err != nil {
+			return fmt.Errorf("failed to parse %s annotation of layer %s: %w", v1.AnnotationFileMetadata, desc.Digest, err)
+		}
+		hdr.Mode = int64(metadata.Mode)
+		hdr.Uid = int(metadata.Uid)
+		hdr.Gid = int(metadata.Gid)
+		hdr.ModTime = metadata.ModTime
+	}
+
+	if err := opts.created.mkdirAll(filepath.Dir(target)); err != nil {
+		return fmt.Errorf("failed to create parent directory of %s: %w", name, err)
+	}
+	if err := writeFile(target, r, hdr, opts); err != nil {
+		return err
+	}
+	return restoreMetadata(target, hdr, opts)
+}
+
+func unpackTar(r io.Reader, dest string, opts Options) error {
+	type dirTime struct {
+		path    string
+		modTime time.Time
+	}
+	var dirs []dirTime
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := opts.Checker.Check(hdr); err != nil {
+			return err
+		}
+
+		target, err := targetPath(dest, hdr.Name)
+		if err != nil {
+			return err
+		}
+		if err := opts.created.mkdirAll(filepath.Dir(target)); err != nil {
+			return fmt.Errorf("failed to create parent directory of %s: %w", hdr.Name, err)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := opts.created.mkdirAll(target); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", hdr.Name, err)
+			}
+			// directory times are restored last as extracting their content updates them
+			if !hdr.ModTime.IsZero() {
+				dirs = append(dirs, dirTime{path: target, modTime: hdr.ModTime})
+			}
+		case tar.TypeReg:
+			if err := writeFile(target, tr, hdr, opts); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return fmt.Errorf("failed to create symlink %s: %w", hdr.Name, err)
+			}
+			opts.created.add(target)
+		case tar.TypeLink:
+			source, err := targetPath(dest, hdr.Linkname)
+			if err != nil {
+				return err
+			}
+			if err := os.Link(source, target); err != nil {
+				return fmt.Errorf("failed to create hard link %s: %w", hdr.Name, err)
+			}
+			opts.created.add(target)
+		default:
+			return fmt.Errorf("unsupported type %q of tar entry %s", hdr.Typeflag, hdr.Name)
+		}
+
+		if err := restoreMetadata(target, hdr, opts); err != nil {
+			return err
+		}
+	}
+
+	// drain the trailing padding of the archive
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return err
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := os.Chtimes(dirs[i].path, dirs[i].modTime, dirs[i].modTime); err != nil {
+			return fmt.Errorf("failed to restore times of %s: %w", dirs[i].path, err)
+		}
+	}
+	return nil
+}
+
+// targetPath returns the location of name inside dest.
+// It refuses paths whose parent directories are symlinks, which could redirect writes outside of dest.
+func targetPath(dest, name string) (string, error) {
+	if err := safepath.Validate(name); err != nil {
+		return "", err
+	}
+	elems := strings.Split(path.Clean(name), "/")
+	parent := dest
+	for _, elem := range elems[:len(elems)-1] {
+		parent = filepath.Join(parent, elem)
+		fi, err := os.Lstat(parent)
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if fi.Mode()&fs.ModeSymlink != 0 {
+			return "", fmt.Errorf("path %q traverses a symlink", name)
+		}
+	}
+	return filepath.Join(dest, filepath.FromSlash(name)), nil
+}
+
+func writeFile(target string, r io.Reader, hdr *tar.Header, opts Options) error {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(hdr.Mode).Perm())
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", hdr.Name, err)
+	}
+	opts.created.add(target)
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write file %s: %w", hdr.Name, err)
+	}
+	return f.Close()
+}
+
+// restoreMetadata applies the mode, ownership and modification time of hdr to target.
+func restoreMetadata(target string, hdr *tar.Header, opts Options) error {
+	if !opts.IgnoreOwnership {
+		if err := os.Lchown(target, hdr.Uid, hdr.Gid); err != nil {
+			return fmt.Errorf("failed to restore ownership of %s: %w", hdr.Name, err)
+		}
+	}
+	if hdr.Typeflag == tar.TypeSymlink {
+		return nil
+	}
+	// chmod explicitly as the mode passed on creation is subject to the umask
+	if err := os.Chmod(target, os.FileMode(hdr.Mode).Perm()); err != nil {
+		return fmt.Errorf("failed to restore mode of %s: %w", hdr.Name, err)
+	}
+	if hdr.Typeflag != tar.TypeDir && !hdr.ModTime.IsZero() {
+		if err := os.Chtimes(target, hdr.ModTime, hdr.ModTime); err != nil {
+			return fmt.Errorf("failed to restore times of %s: %w", hdr.Name, err)
+		}
+	}
+	return nil
+}
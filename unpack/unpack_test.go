@@ -0,0 +1,232 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package unpack_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	"github.com/modelpack/model-spec/unpack"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+var mtime = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// writeBlob stores content in the layout at dir and returns its descriptor.
+func writeBlob(t *testing.T, dir, mediaType string, content []byte) ocispec.Descriptor {
+	t.Helper()
+	dgst := digest.FromBytes(content)
+	path := filepath.Join(dir, "blobs", dgst.Algorithm().String(), dgst.Encoded())
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return ocispec.Descriptor{MediaType: mediaType, Digest: dgst, Size: int64(len(content))}
+}
+
+func writeJSON(t *testing.T, path string, v any) []byte {
+	t.Helper()
+	buf, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != "" {
+		if err := os.WriteFile(path, buf, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf
+}
+
+func tarGzip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0o600,
+			Size:     int64(len(content)),
+			ModTime:  mtime,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// newLayout writes an OCI image layout holding a model artifact with the given layers.
+func newLayout(t *testing.T, layers func(dir string) []ocispec.Descriptor) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeJSON(t, filepath.Join(dir, ocispec.ImageLayoutFile), ocispec.ImageLayout{Version: ocispec.ImageLayoutVersion})
+
+	config := writeBlob(t, dir, v1.MediaTypeModelConfig, []byte(`{}`))
+	manifest := ocispec.Manifest{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: v1.ArtifactTypeModelManifest,
+		Config:       config,
+		Layers:       layers(dir),
+	}
+	manifest.SchemaVersion = 2
+	desc := writeBlob(t, dir, ocispec.MediaTypeImageManifest, writeJSON(t, "", manifest))
+
+	index := ocispec.Index{MediaType: ocispec.MediaTypeImageIndex, Manifests: []ocispec.Descriptor{desc}}
+	index.SchemaVersion = 2
+	writeJSON(t, filepath.Join(dir, ocispec.ImageIndexFile), index)
+	return dir
+}
+
+func TestUnpack(t *testing.T) {
+	metadata := v1.FileMetadata{Name: "model.safetensors", Mode: 0o640, ModTime: mtime, Typeflag: tar.TypeReg}
+	src := newLayout(t, func(dir string) []ocispec.Descriptor {
+		weight := writeBlob(t, dir, v1.MediaTypeModelWeightRaw, []byte("weights"))
+		weight.Annotations = map[string]string{
+			v1.AnnotationFilepath:     "model.safetensors",
+			v1.AnnotationFileMetadata: string(writeJSON(t, "", metadata)),
+		}
+		config := writeBlob(t, dir, v1.MediaTypeModelWeightConfigGzip, tarGzip(t, map[string]string{"config.json": "{}"}))
+		doc := writeBlob(t, dir, v1.MediaTypeModelDocGzip, tarGzip(t, map[string]string{"docs/README.md": "# readme"}))
+		return []ocispec.Descriptor{weight, config, doc}
+	})
+
+	dest := t.TempDir()
+	err := unpack.Unpack(src, dest, unpack.Options{
+		Filter:          unpack.ByComponent(v1.ComponentWeight, v1.ComponentWeightConfig),
+		IgnoreOwnership: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range map[string]string{"model.safetensors": "weights", "config.json": "{}"} {
+		buf, err := os.ReadFile(filepath.Join(dest, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(buf) != content {
+			t.Errorf("%s: expected content %q, got %q", name, content, buf)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dest, "docs", "README.md")); !os.IsNotExist(err) {
+		t.Errorf("expected doc layer to be filtered out, got %v", err)
+	}
+
+	fi, err := os.Stat(filepath.Join(dest, "model.safetensors"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0o640 {
+		t.Errorf("expected mode 0640, got %o", fi.Mode().Perm())
+	}
+	if !fi.ModTime().Equal(mtime) {
+		t.Errorf("expected mtime %s, got %s", mtime, fi.ModTime())
+	}
+}
+
+func TestUnpackDigestMismatch(t *testing.T) {
+	for name, layer := range map[string]struct {
+		mediaType        string
+		content, corrupt []byte
+	}{
+		"raw": {mediaType: v1.MediaTypeModelWeightRaw, content: []byte("weights"), corrupt: []byte("WEIGHTS")},
+		"tar": {
+			mediaType: v1.MediaTypeModelWeightGzip,
+			content:   tarGzip(t, map[string]string{"models/llama/model.safetensors": "weights"}),
+			corrupt:   tarGzip(t, map[string]string{"models/llama/model.safetensors": "WEIGHTS"}),
+		},
+	} {
+		src := newLayout(t, func(dir string) []ocispec.Descriptor {
+			weight := writeBlob(t, dir, layer.mediaType, layer.content)
+			weight.Annotations = map[string]string{v1.AnnotationFilepath: "models/llama/model.safetensors"}
+			// corrupt the blob after its descriptor has been computed
+			if err := os.WriteFile(filepath.Join(dir, "blobs", "sha256", weight.Digest.Encoded()), layer.corrupt, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			return []ocispec.Descriptor{weight}
+		})
+
+		dest := t.TempDir()
+		if err := unpack.Unpack(src, dest, unpack.Options{IgnoreOwnership: true}); err == nil {
+			t.Errorf("%s: expected digest mismatch to fail", name)
+		}
+		entries, err := os.ReadDir(dest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 0 {
+			t.Errorf("%s: expected the entries of the layer to be removed, got %v", name, entries)
+		}
+	}
+}
+
+func TestUnpackPathTraversal(t *testing.T) {
+	src := newLayout(t, func(dir string) []ocispec.Descriptor {
+		weight := writeBlob(t, dir, v1.MediaTypeModelWeightRaw, []byte("weights"))
+		weight.Annotations = map[string]string{v1.AnnotationFilepath: "../../etc/passwd"}
+		return []ocispec.Descriptor{weight}
+	})
+
+	if err := unpack.Unpack(src, t.TempDir(), unpack.Options{IgnoreOwnership: true}); err == nil {
+		t.Error("expected path traversal to fail")
+	}
+}
+
+func TestUnpackThroughSymlink(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, hdr := range []*tar.Header{
+		{Typeflag: tar.TypeDir, Name: "dir/", Mode: 0o755},
+		{Typeflag: tar.TypeSymlink, Name: "link", Linkname: "dir", Mode: 0o777},
+		{Typeflag: tar.TypeReg, Name: "link/model.bin", Mode: 0o644},
+	} {
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	src := newLayout(t, func(dir string) []ocispec.Descriptor {
+		return []ocispec.Descriptor{writeBlob(t, dir, v1.MediaTypeModelWeight, buf.Bytes())}
+	})
+	if err := unpack.Unpack(src, t.TempDir(), unpack.Options{IgnoreOwnership: true}); err == nil {
+		t.Error("expected write through symlink to fail")
+	}
+}
@@ -0,0 +1,329 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package verify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/modelpack/model-spec/content"
+	"github.com/modelpack/model-spec/layout"
+	"github.com/modelpack/model-spec/safepath"
+	"github.com/modelpack/model-spec/schema"
+	"github.com/modelpack/model-spec/sniff"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Finding is an inconsistency found in a model artifact.
+type Finding struct {
+	// Subject locates the inconsistent object, such as "manifest sha256:...", "config" or "layer 2".
+	Subject string `json:"subject"`
+
+	// Message describes the inconsistency.
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	return f.Subject + ": " + f.Message
+}
+
+// Report is the result of verifying an OCI image layout.
+type Report struct {
+	// Manifests are the model manifests which have been verified.
+	Manifests []ocispec.Descriptor `json:"manifests"`
+
+	// Findings are the inconsistencies found, empty if the artifact is consistent.
+	Findings []Finding `json:"findings"`
+}
+
+// OK reports whether no inconsistency was found.
+func (r *Report) OK() bool {
+	return len(r.Findings) == 0
+}
+
+func (r *Report) addf(subject, format string, args ...any) {
+	r.Findings = append(r.Findings, Finding{Subject: subject, Message: fmt.Sprintf(format, args...)})
+}
+
+// Path verifies the OCI image layout stored at path, either a directory or an uncompressed tarball.
+func Path(path string) (*Report, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var l *layout.Layout
+	if fi.IsDir() {
+		l, err = layout.Open(path)
+	} else {
+		l, err = layout.OpenTarball(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+
+	return Layout(l)
+}
+
+// Layout cross-checks every model manifest of the layout index, and of the image indexes it references,
+// with its config and layers: blob digests and sizes, DiffIDs and their order, the compression of the
+// layers against their media type, and the layer annotations. The variant annotations of the nested
+// indexes are validated when present.
+// An error is only returned if the index cannot be read, inconsistencies are listed in the report.
+func Layout(l *layout.Layout) (*Report, error) {
+	index, err := l.Index()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Manifests: []ocispec.Descriptor{}, Findings: []Finding{}}
+	if len(index.Manifests) == 0 {
+		report.addf(ocispec.ImageIndexFile, "index has no manifests")
+	}
+	verifyManifests(l, index.Manifests, report)
+	return report, nil
+}
+
+func verifyManifests(l *layout.Layout, descs []ocispec.Descriptor, report *Report) {
+	for _, desc := range descs {
+		switch desc.MediaType {
+		case ocispec.MediaTypeImageManifest:
+			verifyManifest(l, desc, report)
+		case ocispec.MediaTypeImageIndex:
+			verifyIndex(l, desc, report)
+		default:
+			report.addf("manifest "+desc.Digest.String(), "unsupported media type %q", desc.MediaType)
+		}
+	}
+}
+
+// verifyIndex verifies the manifests of a nested image index, such as an index of model variants.
+// Image indexes cannot reference themselves, blobs being content-addressed, so the recursion ends.
+func verifyIndex(l *layout.Layout, desc ocispec.Descriptor, report *Report) {
+	subject := "index " + desc.Digest.String()
+	buf, ok := readBlob(l, desc, subject, report)
+	if !ok {
+		return
+	}
+
+	var index v1.ModelIndex
+	if err := json.Unmarshal(buf, &index); err != nil {
+		report.addf(subject, "invalid index: %v", err)
+		return
+	}
+	for _, m := range index.Manifests {
+		if variant, err := v1.ParseModelVariant(m.Annotations); err != nil || variant != (v1.ModelVariant{}) {
+			if err := index.Validate(); err != nil {
+				report.addf(subject, "invalid model index: %v", err)
+			}
+			break
+		}
+	}
+	verifyManifests(l, index.Manifests, report)
+}
+
+func verifyManifest(l *layout.Layout, desc ocispec.Descriptor, report *Report) {
+	subject := "manifest " + desc.Digest.String()
+	buf, ok := readBlob(l, desc, subject, report)
+	if !ok {
+		return
+	}
+
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(buf, &manifest); err != nil {
+		report.addf(subject, "invalid manifest: %v", err)
+		return
+	}
+	if manifest.ArtifactType != v1.ArtifactTypeModelManifest {
+		// not a model artifact, nothing to verify
+		return
+	}
+	report.Manifests = append(report.Manifests, desc)
+
+	if manifest.SchemaVersion != 2 {
+		report.addf(subject, "schemaVersion is %d, expected 2", manifest.SchemaVersion)
+	}
+	if manifest.MediaType != ocispec.MediaTypeImageManifest {
+		report.addf(subject, "mediaType is %q, expected %q", manifest.MediaType, ocispec.MediaTypeImageManifest)
+	}
+	if err := safepath.ValidateManifest(manifest); err != nil {
+		report.addf(subject, "unsafe file paths: %v", err)
+	}
+
+	model, ok := verifyConfig(l, manifest.Config, report)
+	var diffIDs []digest.Digest
+	if ok {
+		diffIDs = model.ModelFS.DiffIDs
+		if len(diffIDs) != len(manifest.Layers) {
+			report.addf("config", "modelfs has %d diffIds but the manifest has %d layers", len(diffIDs), len(manifest.Layers))
+		}
+		adapters := len(v1.ModelManifest{Manifest: manifest}.LayersByComponent(v1.ComponentAdapter))
+		if adapters > 0 && model.Config.Adapter == nil {
+			report.addf("config", "manifest has %d adapter layers but config.adapter is not set", adapters)
+		}
+	}
+
+	for i, layer := range manifest.Layers {
+		verifyLayer(l, i, layer, diffIDs, report)
+	}
+}
+
+func verifyConfig(l *layout.Layout, desc ocispec.Descriptor, report *Report) (v1.Model, bool) {
+	var model v1.Model
+	if desc.MediaType != v1.MediaTypeModelConfig {
+		report.addf("config", "mediaType is %q, expected %q", desc.MediaType, v1.MediaTypeModelConfig)
+		return model, false
+	}
+	buf, ok := readBlob(l, desc, "config", report)
+	if !ok {
+		return model, false
+	}
+	if err := schema.ValidatorMediaTypeModelConfig.Validate(bytes.NewReader(buf)); err != nil {
+		report.addf("config", "%v", err)
+		return model, false
+	}
+	if err := json.Unmarshal(buf, &model); err != nil {
+		report.addf("config", "%v", err)
+		return model, false
+	}
+	return model, true
+}
+
+func verifyLayer(l *layout.Layout, i int, desc ocispec.Descriptor, diffIDs []digest.Digest, report *Report) {
+	subject := "layer " + strconv.Itoa(i)
+	mt, err := v1.ParseLayerMediaType(desc.MediaType)
+	if err != nil {
+		report.addf(subject, "%v", err)
+		return
+	}
+	verifyAnnotations(subject, desc, mt, report)
+
+	if !verifyPackaging(l, subject, desc, report) {
+		return
+	}
+
+	f, err := l.OpenBlob(desc.Digest)
+	if err != nil {
+		report.addf(subject, "%v", err)
+		return
+	}
+	defer f.Close()
+
+	computed, err := content.ComputeLayer(f, desc.MediaType)
+	if err != nil {
+		report.addf(subject, "%v", err)
+		return
+	}
+	if computed.Descriptor.Size != desc.Size {
+		report.addf(subject, "blob size is %d, descriptor declares %d", computed.Descriptor.Size, desc.Size)
+	}
+	if computed.Descriptor.Digest != desc.Digest {
+		report.addf(subject, "blob digest is %s, descriptor declares %s", computed.Descriptor.Digest, desc.Digest)
+	}
+
+	if diffIDs == nil {
+		return
+	}
+	if i < len(diffIDs) && diffIDs[i] == computed.DiffID {
+		return
+	}
+	for j, diffID := range diffIDs {
+		if diffID == computed.DiffID {
+			report.addf(subject, "DiffID %s is listed at position %d of modelfs.diffIds", computed.DiffID, j)
+			return
+		}
+	}
+	report.addf(subject, "DiffID %s is missing from modelfs.diffIds", computed.DiffID)
+}
+
+// verifyPackaging checks the compression and archive format of the blob against its media type,
+// it returns false if the blob cannot be decoded as declared.
+func verifyPackaging(l *layout.Layout, subject string, desc ocispec.Descriptor, report *Report) bool {
+	f, err := l.OpenBlob(desc.Digest)
+	if err != nil {
+		report.addf(subject, "%v", err)
+		return false
+	}
+	defer f.Close()
+
+	result, err := sniff.Detect(f, desc.MediaType)
+	if err != nil {
+		report.addf(subject, "%v", err)
+		return false
+	}
+	result.Filepath = desc.Annotations[v1.AnnotationFilepath]
+	mismatches := result.Mismatches()
+	for _, mismatch := range mismatches {
+		report.addf(subject, "%s", mismatch)
+	}
+	return len(mismatches) == 0
+}
+
+func verifyAnnotations(subject string, desc ocispec.Descriptor, mt v1.LayerMediaType, report *Report) {
+	if !mt.Archived && desc.Annotations[v1.AnnotationFilepath] == "" {
+		report.addf(subject, "raw layer has no %s annotation", v1.AnnotationFilepath)
+	}
+	if raw, ok := desc.Annotations[v1.AnnotationFileMetadata]; ok {
+		var metadata v1.FileMetadata
+		if err := json.Unmarshal([]byte(raw), &metadata); err != nil {
+			report.addf(subject, "invalid %s annotation: %v", v1.AnnotationFileMetadata, err)
+		} else if !mt.Archived && metadata.Size != desc.Size {
+			report.addf(subject, "%s annotation declares size %d, the raw layer has %d", v1.AnnotationFileMetadata, metadata.Size, desc.Size)
+		}
+	}
+	if v, ok := desc.Annotations[v1.AnnotationMediaTypeUntested]; ok && v != "true" && v != "false" {
+		report.addf(subject, "%s annotation is %q, expected \"true\" or \"false\"", v1.AnnotationMediaTypeUntested, v)
+	}
+}
+
+// readBlob reads a small blob, reporting digest and size mismatches.
+// Blobs declared larger than layout.MaxManifestSize are reported without being read.
+func readBlob(l *layout.Layout, desc ocispec.Descriptor, subject string, report *Report) ([]byte, bool) {
+	if desc.Size < 0 || desc.Size > layout.MaxManifestSize {
+		report.addf(subject, "declared size %d is out of range, the limit is %d bytes", desc.Size, layout.MaxManifestSize)
+		return nil, false
+	}
+	f, err := l.OpenBlob(desc.Digest)
+	if err != nil {
+		report.addf(subject, "%v", err)
+		return nil, false
+	}
+	defer f.Close()
+
+	// read one byte past the declared size to detect larger blobs without reading them whole
+	buf, err := io.ReadAll(io.LimitReader(f, desc.Size+1))
+	if err != nil {
+		report.addf(subject, "failed to read blob: %v", err)
+		return nil, false
+	}
+	if int64(len(buf)) != desc.Size {
+		report.addf(subject, "blob size differs from the declared size %d", desc.Size)
+		return nil, false
+	}
+	if desc.Digest.Algorithm().FromBytes(buf) != desc.Digest {
+		report.addf(subject, "blob does not match digest %s", desc.Digest)
+		return nil, false
+	}
+	return buf, true
+}
@@ -0,0 +1,320 @@
+/*
+ *     Copyright 2025 The CNCF ModelPack Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package verify_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelpack/model-spec/archive"
+	"github.com/modelpack/model-spec/content"
+	v1 "github.com/modelpack/model-spec/specs-go/v1"
+	"github.com/modelpack/model-spec/verify"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+type blob struct {
+	mediaType string
+	content   []byte
+}
+
+func writeFile(t *testing.T, path string, buf []byte) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeBlob(t *testing.T, dir string, buf []byte) digest.Digest {
+	t.Helper()
+	dgst := digest.FromBytes(buf)
+	writeFile(t, filepath.Join(dir, "blobs", "sha256", dgst.Encoded()), buf)
+	return dgst
+}
+
+func marshal(t *testing.T, v any) []byte {
+	t.Helper()
+	buf, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf
+}
+
+// newLayout writes an OCI image layout holding a model artifact made of the given layers,
+// mutate may alter the config and manifest before they are stored.
+func newLayout(t *testing.T, blobs []blob, mutate func(*v1.Model, *ocispec.Manifest)) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ocispec.ImageLayoutFile), marshal(t, ocispec.ImageLayout{Version: ocispec.ImageLayoutVersion}))
+
+	manifest := ocispec.Manifest{MediaType: ocispec.MediaTypeImageManifest, ArtifactType: v1.ArtifactTypeModelManifest}
+	manifest.SchemaVersion = 2
+	var layers []content.Layer
+	for i, b := range blobs {
+		layer, err := content.ComputeLayer(bytes.NewReader(b.content), b.mediaType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeBlob(t, dir, b.content)
+		layer.Descriptor.Annotations = map[string]string{v1.AnnotationFilepath: "file" + string(rune('a'+i))}
+		layers = append(layers, layer)
+		manifest.Layers = append(manifest.Layers, layer.Descriptor)
+	}
+	model := v1.Model{
+		Descriptor: v1.ModelDescriptor{Name: "xyz-3-8B-Instruct"},
+		Config:     v1.ModelConfig{ParamSize: "8b"},
+		ModelFS:    content.ModelFS(layers),
+	}
+	if mutate != nil {
+		mutate(&model, &manifest)
+	}
+
+	config, buf, err := content.ConfigDescriptor(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeBlob(t, dir, buf)
+	manifest.Config = config
+
+	buf = marshal(t, manifest)
+	desc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: writeBlob(t, dir, buf), Size: int64(len(buf))}
+	index := ocispec.Index{MediaType: ocispec.MediaTypeImageIndex, Manifests: []ocispec.Descriptor{desc}}
+	index.SchemaVersion = 2
+	writeFile(t, filepath.Join(dir, ocispec.ImageIndexFile), marshal(t, index))
+	return dir
+}
+
+func tarBytes(t *testing.T, name, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0o644, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func gzipBytes(t *testing.T, p []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(p); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestVerify(t *testing.T) {
+	weights := blob{mediaType: v1.MediaTypeModelWeightRaw, content: []byte("weights")}
+	docs := blob{mediaType: v1.MediaTypeModelDocGzip, content: gzipBytes(t, tarBytes(t, "README.md", "docs"))}
+
+	for _, tt := range []struct {
+		name     string
+		blobs    []blob
+		mutate   func(*v1.Model, *ocispec.Manifest)
+		findings []string
+	}{
+		{
+			name:  "consistent",
+			blobs: []blob{weights, docs},
+		},
+		{
+			name:  "diffIds count",
+			blobs: []blob{weights, docs},
+			mutate: func(m *v1.Model, _ *ocispec.Manifest) {
+				m.ModelFS.DiffIDs = m.ModelFS.DiffIDs[:1]
+			},
+			findings: []string{"config: modelfs has 1 diffIds", "layer 1: DiffID"},
+		},
+		{
+			name:  "diffIds order",
+			blobs: []blob{weights, docs},
+			mutate: func(m *v1.Model, _ *ocispec.Manifest) {
+				slices.Reverse(m.ModelFS.DiffIDs)
+			},
+			findings: []string{"layer 0: DiffID", "layer 1: DiffID"},
+		},
+		{
+			name:  "gzip layer is uncompressed",
+			blobs: []blob{{mediaType: v1.MediaTypeModelDoc, content: tarBytes(t, "README.md", "docs")}},
+			mutate: func(_ *v1.Model, m *ocispec.Manifest) {
+				m.Layers[0].MediaType = v1.MediaTypeModelDocGzip
+			},
+			findings: []string{"layer 0: declared compression gzip, detected uncompressed"},
+		},
+		{
+			name:  "size mismatch",
+			blobs: []blob{weights},
+			mutate: func(_ *v1.Model, m *ocispec.Manifest) {
+				m.Layers[0].Size++
+			},
+			findings: []string{"layer 0: blob size is 7, descriptor declares 8"},
+		},
+		{
+			name:  "missing filepath",
+			blobs: []blob{weights},
+			mutate: func(_ *v1.Model, m *ocispec.Manifest) {
+				m.Layers[0].Annotations = nil
+			},
+			findings: []string{"layer 0: raw layer has no org.cncf.model.filepath annotation"},
+		},
+		{
+			name:     "raw layer is a tar archive",
+			blobs:    []blob{{mediaType: v1.MediaTypeModelWeightRaw, content: tarBytes(t, "model.safetensors", "weights")}},
+			findings: []string{"layer 0: declared raw file, detected tar archive"},
+		},
+		{
+			name:  "raw layer is a tar file",
+			blobs: []blob{{mediaType: v1.MediaTypeModelDatasetRaw, content: tarBytes(t, "data.jsonl", "{}")}},
+			mutate: func(_ *v1.Model, m *ocispec.Manifest) {
+				m.Layers[0].Annotations[v1.AnnotationFilepath] = "data/shard-0.tar"
+			},
+		},
+		{
+			name:  "raw layer is a gzip file",
+			blobs: []blob{{mediaType: v1.MediaTypeModelDatasetRaw, content: gzipBytes(t, []byte(`{"text": "x"}`))}},
+		},
+		{
+			name:     "adapter without adapter config",
+			blobs:    []blob{{mediaType: v1.MediaTypeModelAdapterRaw, content: []byte("adapter")}},
+			findings: []string{"config: manifest has 1 adapter layers but config.adapter is not set"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := verify.Path(newLayout(t, tt.blobs, tt.mutate))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(report.Manifests) != 1 {
+				t.Errorf("expected 1 verified manifest, got %d", len(report.Manifests))
+			}
+			if len(report.Findings) != len(tt.findings) {
+				t.Fatalf("expected findings %q, got %v", tt.findings, report.Findings)
+			}
+			for i, finding := range report.Findings {
+				if !strings.HasPrefix(finding.String(), tt.findings[i]) {
+					t.Errorf("expected finding %q, got %q", tt.findings[i], finding)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyTarball(t *testing.T) {
+	dir := newLayout(t, []blob{{mediaType: v1.MediaTypeModelWeightRaw, content: []byte("weights")}}, nil)
+
+	tarball := filepath.Join(t.TempDir(), "model.tar")
+	f, err := os.Create(tarball)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := archive.Write(f, dir, []string{ocispec.ImageLayoutFile, ocispec.ImageIndexFile, ocispec.ImageBlobsDir}, archive.Options{}); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := verify.Path(tarball)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() || len(report.Manifests) != 1 {
+		t.Errorf("expected tarball to verify, got %+v", report)
+	}
+}
+
+func TestVerifyIndex(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		mutate   func(*ocispec.Descriptor)
+		findings []string
+	}{
+		{
+			name: "variant index",
+		},
+		{
+			name: "invalid variant annotation",
+			mutate: func(desc *ocispec.Descriptor) {
+				desc.Annotations = map[string]string{v1.AnnotationVariantParamSize: "huge"}
+			},
+			findings: []string{"index sha256:"},
+		},
+		{
+			name: "oversized manifest",
+			mutate: func(desc *ocispec.Descriptor) {
+				desc.Size = 1 << 40
+			},
+			findings: []string{"manifest sha256:"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := newLayout(t, []blob{{mediaType: v1.MediaTypeModelWeightRaw, content: []byte("weights")}}, nil)
+			buf, err := os.ReadFile(filepath.Join(dir, ocispec.ImageIndexFile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var index ocispec.Index
+			if err := json.Unmarshal(buf, &index); err != nil {
+				t.Fatal(err)
+			}
+			desc := v1.NewVariantDescriptor(index.Manifests[0], v1.ModelVariant{ParamSize: "8b"})
+			desc.ArtifactType = v1.ArtifactTypeModelManifest
+			if tt.mutate != nil {
+				tt.mutate(&desc)
+			}
+			buf = marshal(t, v1.NewModelIndex(desc))
+			index.Manifests = []ocispec.Descriptor{{MediaType: ocispec.MediaTypeImageIndex, Digest: writeBlob(t, dir, buf), Size: int64(len(buf))}}
+			writeFile(t, filepath.Join(dir, ocispec.ImageIndexFile), marshal(t, index))
+
+			report, err := verify.Path(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(report.Findings) != len(tt.findings) {
+				t.Fatalf("expected findings %q, got %v", tt.findings, report.Findings)
+			}
+			for i, finding := range report.Findings {
+				if !strings.HasPrefix(finding.String(), tt.findings[i]) {
+					t.Errorf("expected finding %q, got %q", tt.findings[i], finding)
+				}
+			}
+			if tt.findings == nil && len(report.Manifests) != 1 {
+				t.Errorf("expected 1 verified manifest, got %d", len(report.Manifests))
+			}
+		})
+	}
+}